@@ -0,0 +1,173 @@
+package rxgo
+
+import (
+	"context"
+	"fmt"
+)
+
+// buildOutputChannel returns the channel an operator emits to and the channel observed downstream.
+// With the Block strategy and without spill buffer, both are the same channel. Otherwise, a relay applies the
+// strategy or the spill buffer whenever the downstream is not ready to consume an item.
+func buildOutputChannel(ctx context.Context, option, stageOption Option) (chan Item, chan Item) {
+	next := option.buildChannel()
+	if spill := stageOption.getSpillBuffer(); spill != nil {
+		in := make(chan Item)
+		out := make(chan Item)
+		capacity := cap(next)
+		if capacity < 1 {
+			capacity = 1
+		}
+		go relaySpill(ctx, in, out, capacity, spill, stageOption.getOnDrop())
+		return in, out
+	}
+	strategy := stageOption.getBackPressureStrategy()
+	if strategy == Block {
+		return next, next
+	}
+	return newBackpressureRelay(ctx, next, strategy, stageOption.getOnDrop())
+}
+
+// newBackpressureRelay starts a relay forwarding the items to next according to a strategy other than Block.
+// It returns the channel to emit to, closing it closes the channel to observe once the buffered items are
+// consumed.
+func newBackpressureRelay(ctx context.Context, next chan Item, strategy BackpressureStrategy, onDrop func(Item)) (chan Item, chan Item) {
+	in := make(chan Item)
+	return in, relayBackpressure(ctx, in, next, strategy, onDrop)
+}
+
+// relayBackpressure starts a relay forwarding the items of a channel to next according to a strategy other than
+// Block. It returns the channel to observe, closed once in is closed and the buffered items are consumed.
+func relayBackpressure(ctx context.Context, in <-chan Item, next chan Item, strategy BackpressureStrategy, onDrop func(Item)) chan Item {
+	if strategy == Drop {
+		go relayDrop(ctx, in, next, onDrop)
+		return next
+	}
+
+	// The items are buffered by the relay only, the channel to observe is not buffered so that the buffer
+	// always contains the most recent items.
+	out := make(chan Item)
+	capacity := cap(next)
+	if strategy == Latest || capacity < 1 {
+		capacity = 1
+	}
+	go relayRingBuffer(ctx, in, out, strategy, capacity, onDrop)
+	return out
+}
+
+func relayDrop(ctx context.Context, in <-chan Item, out chan<- Item, onDrop func(Item)) {
+	defer close(out)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case item, ok := <-in:
+			if !ok {
+				return
+			}
+			select {
+			case out <- item:
+			default:
+				if onDrop != nil {
+					onDrop(item)
+				}
+			}
+		}
+	}
+}
+
+func relayRingBuffer(ctx context.Context, in <-chan Item, out chan<- Item, strategy BackpressureStrategy, capacity int, onDrop func(Item)) {
+	defer close(out)
+	buffer := newRingBuffer(capacity)
+	overflowed := false
+	drop := func(item Item) {
+		if onDrop != nil {
+			onDrop(item)
+		}
+	}
+
+	for in != nil || buffer.len() > 0 {
+		// A nil channel is never selected
+		var send chan<- Item
+		var head Item
+		if buffer.len() > 0 {
+			send = out
+			head = buffer.peek()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case send <- head:
+			buffer.pop()
+		case item, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+			if overflowed {
+				drop(item)
+				continue
+			}
+			if !buffer.full() {
+				buffer.push(item)
+				continue
+			}
+			if strategy == BufferThenError {
+				// The error is emitted once the buffered items are consumed, the next items are dropped
+				overflowed = true
+				drop(item)
+				buffer.grow()
+				buffer.push(Error(BufferOverflowError{error: fmt.Sprintf("capacity of %d items exceeded", capacity)}))
+				continue
+			}
+			drop(buffer.pop())
+			buffer.push(item)
+		}
+	}
+}
+
+// ringBuffer is a FIFO queue of items with a fixed capacity.
+type ringBuffer struct {
+	items []Item
+	start int
+	size  int
+}
+
+func newRingBuffer(capacity int) *ringBuffer {
+	return &ringBuffer{items: make([]Item, capacity)}
+}
+
+func (r *ringBuffer) len() int {
+	return r.size
+}
+
+func (r *ringBuffer) full() bool {
+	return r.size == len(r.items)
+}
+
+func (r *ringBuffer) peek() Item {
+	return r.items[r.start]
+}
+
+func (r *ringBuffer) push(item Item) {
+	r.items[(r.start+r.size)%len(r.items)] = item
+	r.size++
+}
+
+func (r *ringBuffer) pop() Item {
+	item := r.items[r.start]
+	r.items[r.start] = Item{}
+	r.start = (r.start + 1) % len(r.items)
+	r.size--
+	return item
+}
+
+// grow adds one slot to the buffer.
+func (r *ringBuffer) grow() {
+	items := make([]Item, len(r.items)+1)
+	for i := 0; i < r.size; i++ {
+		items[i] = r.items[(r.start+i)%len(r.items)]
+	}
+	r.items = items
+	r.start = 0
+}
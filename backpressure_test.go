@@ -0,0 +1,122 @@
+package rxgo
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func identity(_ context.Context, i interface{}) (interface{}, error) {
+	return i, nil
+}
+
+// awaitDrops waits until a number of items have been dropped.
+func awaitDrops(t *testing.T, dropped *int32, n int32) {
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(dropped) == n
+	}, time.Second, time.Millisecond)
+}
+
+func Test_Backpressure_Drop(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var dropped int32
+	obs := testObservable(ctx, 1, 2, 3, 4, 5).Map(identity, WithContext(ctx), WithBufferedChannel(2),
+		WithBackPressureStrategy(Drop), WithOnDrop(func(Item) {
+			atomic.AddInt32(&dropped, 1)
+		}))
+	observe := obs.Observe()
+	awaitDrops(t, &dropped, 3)
+	Assert(ctx, t, &ObservableImpl{iterable: newChannelIterable(observe)}, HasItems(1, 2), HasNoError())
+}
+
+func Test_Backpressure_Latest(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var dropped int32
+	obs := testObservable(ctx, 1, 2, 3, 4, 5).Map(identity, WithContext(ctx), WithBufferedChannel(3),
+		WithBackPressureStrategy(Latest), WithOnDrop(func(Item) {
+			atomic.AddInt32(&dropped, 1)
+		}))
+	observe := obs.Observe()
+	awaitDrops(t, &dropped, 4)
+	Assert(ctx, t, &ObservableImpl{iterable: newChannelIterable(observe)}, HasItems(5), HasNoError())
+}
+
+func Test_Backpressure_DropOldest(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var dropped []interface{}
+	droppedCount := int32(0)
+	obs := testObservable(ctx, 1, 2, 3, 4, 5).Map(identity, WithContext(ctx), WithBufferedChannel(3),
+		WithBackPressureStrategy(DropOldest), WithOnDrop(func(item Item) {
+			dropped = append(dropped, item.V)
+			atomic.AddInt32(&droppedCount, 1)
+		}))
+	observe := obs.Observe()
+	awaitDrops(t, &droppedCount, 2)
+	Assert(ctx, t, &ObservableImpl{iterable: newChannelIterable(observe)}, HasItems(3, 4, 5), HasNoError())
+	assert.Equal(t, []interface{}{1, 2}, dropped)
+}
+
+func Test_Backpressure_BufferThenError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var dropped int32
+	obs := testObservable(ctx, 1, 2, 3, 4, 5).Map(identity, WithContext(ctx), WithBufferedChannel(3),
+		WithBackPressureStrategy(BufferThenError), WithOnDrop(func(Item) {
+			atomic.AddInt32(&dropped, 1)
+		}))
+	observe := obs.Observe()
+	awaitDrops(t, &dropped, 2)
+	Assert(ctx, t, &ObservableImpl{iterable: newChannelIterable(observe)}, HasItems(1, 2, 3),
+		HasError(BufferOverflowError{error: "capacity of 3 items exceeded"}))
+}
+
+func Test_Backpressure_BufferThenError_NoOverflow(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obs := testObservable(ctx, 1, 2, 3).Map(identity, WithContext(ctx), WithBufferedChannel(3),
+		WithBackPressureStrategy(BufferThenError))
+	Assert(ctx, t, obs, HasItems(1, 2, 3), HasNoError())
+}
+
+func Test_Backpressure_Single(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obs := testObservable(ctx, 1, 2, 3).Count(WithContext(ctx), WithBackPressureStrategy(Latest))
+	Assert(ctx, t, obs, HasItem(int64(3)), HasNoError())
+}
+
+func Test_RingBuffer(t *testing.T) {
+	r := newRingBuffer(2)
+	r.push(Of(1))
+	r.push(Of(2))
+	assert.True(t, r.full())
+	assert.Equal(t, Of(1), r.pop())
+	r.push(Of(3))
+	r.grow()
+	r.push(Of(4))
+	assert.Equal(t, 3, r.len())
+	assert.Equal(t, Of(2), r.pop())
+	assert.Equal(t, Of(3), r.peek())
+	assert.Equal(t, Of(3), r.pop())
+	assert.Equal(t, Of(4), r.pop())
+	assert.Equal(t, 0, r.len())
+}
@@ -0,0 +1,244 @@
+package rxgo
+
+import (
+	"context"
+	"time"
+)
+
+type batchTransport struct {
+	size   int
+	linger time.Duration
+}
+
+// batchSource is implemented by an Iterable able to emit its items in batches.
+type batchSource interface {
+	observeBatches(opts ...Option) <-chan []Item
+}
+
+// batchIterable runs a sequential operator emitting at most one item per item received. It consumes the batches of
+// the previous operator if it uses a batch transport, and emits batches to the next operator if it also uses one.
+// Otherwise, the items are exchanged one by one.
+type batchIterable struct {
+	parent          context.Context
+	source          Iterable
+	operatorFactory func() operator
+	opts            []Option
+}
+
+func newBatchIterable(parent context.Context, source Iterable, operatorFactory func() operator, opts ...Option) Iterable {
+	return &batchIterable{
+		parent:          parent,
+		source:          source,
+		operatorFactory: operatorFactory,
+		opts:            opts,
+	}
+}
+
+func (i *batchIterable) Observe(opts ...Option) <-chan Item {
+	mergedOptions := append(append([]Option{}, i.opts...), opts...)
+	option := parseOptions(mergedOptions...)
+	next := option.buildChannel()
+	ctx := option.buildContext(i.parent)
+
+	go func() {
+		defer close(next)
+		i.run(ctx, &itemWriter{ctx: ctx, next: next}, mergedOptions...)
+	}()
+	return next
+}
+
+func (i *batchIterable) observeBatches(opts ...Option) <-chan []Item {
+	mergedOptions := append(append([]Option{}, i.opts...), opts...)
+	option := parseOptions(mergedOptions...)
+	next := make(chan []Item)
+	ctx := option.buildContext(i.parent)
+	transport := parseOptions(i.opts...).getBatchTransport()
+
+	go func() {
+		defer close(next)
+		i.run(ctx, &batchWriter{
+			ctx:    ctx,
+			next:   next,
+			size:   transport.size,
+			linger: transport.linger,
+		}, mergedOptions...)
+	}()
+	return next
+}
+
+func (i *batchIterable) run(ctx context.Context, w transportWriter, opts ...Option) {
+	option := parseOptions(opts...)
+	stageOption := parseOptions(i.opts...)
+	op := i.operatorFactory()
+	// The operator emits to a single slot buffer, drained after each item
+	dst := make(chan Item, 1)
+	stopped := false
+	var items <-chan Item
+	var batches <-chan []Item
+	if src, ok := observableBatchSource(i.source); ok {
+		batches = src.observeBatches(opts...)
+	} else {
+		items = i.source.Observe(opts...)
+	}
+	operatorOptions := operatorOptions{
+		stop: func() {
+			if option.getErrorStrategy() == StopOnError {
+				stopped = true
+			}
+		},
+		resetIterable: func(newIterable Iterable) {
+			items = newIterable.Observe(opts...)
+			batches = nil
+		},
+		name:       stageOption.getName(),
+		deadLetter: stageOption.getDeadLetter(),
+	}
+
+	drain := func() bool {
+		for {
+			select {
+			case item := <-dst:
+				if !w.write(item) {
+					return false
+				}
+			default:
+				return true
+			}
+		}
+	}
+	syncOp, isSync := op.(syncOperator)
+	process := func(item Item) bool {
+		switch {
+		case item.Error():
+			op.err(ctx, item, dst, operatorOptions)
+		case isSync:
+			if res, ok := syncOp.process(ctx, item, operatorOptions); ok {
+				return w.write(res)
+			}
+			return true
+		default:
+			op.next(ctx, item, dst, operatorOptions)
+		}
+		return drain()
+	}
+
+loop:
+	for !stopped {
+		select {
+		case <-ctx.Done():
+			break loop
+		case batch, ok := <-batches:
+			if !ok {
+				break loop
+			}
+			for _, item := range batch {
+				if stopped {
+					break
+				}
+				if !process(item) {
+					return
+				}
+			}
+		case item, ok := <-items:
+			if !ok {
+				break loop
+			}
+			if !process(item) {
+				return
+			}
+		case <-w.lingerC():
+			if !w.flush() {
+				return
+			}
+		}
+	}
+	op.end(ctx, dst)
+	if drain() {
+		w.flush()
+	}
+}
+
+func observableBatchSource(iterable Iterable) (batchSource, bool) {
+	if o, ok := iterable.(*ObservableImpl); ok {
+		iterable = o.iterable
+	}
+	src, ok := iterable.(batchSource)
+	return src, ok
+}
+
+// transportWriter emits the items of a batchIterable.
+type transportWriter interface {
+	// write emits an item. It returns false if the context is canceled.
+	write(item Item) bool
+	// flush emits the pending items. It returns false if the context is canceled.
+	flush() bool
+	// lingerC is notified once the pending items have to be flushed.
+	lingerC() <-chan time.Time
+}
+
+type itemWriter struct {
+	ctx  context.Context
+	next chan<- Item
+}
+
+func (w *itemWriter) write(item Item) bool {
+	return item.SendContext(w.ctx, w.next)
+}
+
+func (w *itemWriter) flush() bool {
+	return true
+}
+
+func (w *itemWriter) lingerC() <-chan time.Time {
+	return nil
+}
+
+// batchWriter emits the items in batches of a given size. A batch is emitted earlier once the linger duration has
+// elapsed since its first item.
+type batchWriter struct {
+	ctx    context.Context
+	next   chan<- []Item
+	size   int
+	linger time.Duration
+	batch  []Item
+	timer  *time.Timer
+}
+
+func (w *batchWriter) write(item Item) bool {
+	if w.batch == nil {
+		w.batch = make([]Item, 0, w.size)
+		if w.linger > 0 {
+			w.timer = time.NewTimer(w.linger)
+		}
+	}
+	w.batch = append(w.batch, item)
+	if len(w.batch) >= w.size {
+		return w.flush()
+	}
+	return true
+}
+
+func (w *batchWriter) flush() bool {
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	if len(w.batch) == 0 {
+		return true
+	}
+	batch := w.batch
+	w.batch = nil
+	select {
+	case <-w.ctx.Done():
+		return false
+	case w.next <- batch:
+		return true
+	}
+}
+
+func (w *batchWriter) lingerC() <-chan time.Time {
+	if w.timer == nil {
+		return nil
+	}
+	return w.timer.C
+}
@@ -0,0 +1,129 @@
+package rxgo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func Test_BatchTransport(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obs := testObservable(ctx, 1, 2, 3, 4, 5).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) * 10, nil
+		}, WithBatchTransport(2, nil)).
+		Filter(func(i interface{}) bool {
+			return i.(int) > 10
+		}, WithBatchTransport(2, nil)).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) + 1, nil
+		}, WithBatchTransport(2, nil))
+	_, ok := observableBatchSource(obs)
+	assert.True(t, ok)
+	Assert(ctx, t, obs, HasItems(21, 31, 41, 51), HasNoError())
+}
+
+func Test_BatchTransport_Linger(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	next := make(chan Item)
+
+	observe := FromChannel(next).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithBatchTransport(100, WithDuration(10*time.Millisecond))).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithBatchTransport(100, WithDuration(10*time.Millisecond))).
+		Observe(WithContext(ctx))
+
+	// The partial batches are emitted once the linger duration has elapsed
+	next <- Of(1)
+	next <- Of(2)
+	assert.Equal(t, Of(1), <-observe)
+	assert.Equal(t, Of(2), <-observe)
+	close(next)
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_BatchTransport_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obs := testObservable(ctx, 1, 2, 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			if i == 2 {
+				return nil, errFoo
+			}
+			return i, nil
+		}, WithBatchTransport(10, nil)).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithBatchTransport(10, nil))
+	Assert(ctx, t, obs, HasItems(1), HasError(errFoo))
+}
+
+func Test_BatchTransport_Reduce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obs := Range(1, 100).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithBatchTransport(16, nil)).
+		Reduce(func(_ context.Context, acc, elem interface{}) (interface{}, error) {
+			if acc == nil {
+				return elem, nil
+			}
+			return acc.(int) + elem.(int), nil
+		}, WithBatchTransport(16, nil))
+	Assert(ctx, t, obs, HasItem(5050), HasNoError())
+}
+
+func Test_BatchTransport_Cancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	next := make(chan Item)
+
+	observe := FromChannel(next).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithBatchTransport(1, nil)).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithBatchTransport(1, nil)).
+		Observe(WithContext(ctx))
+	next <- Of(1)
+	assert.Equal(t, Of(1), <-observe)
+	cancel()
+	for range observe {
+	}
+	close(next)
+}
+
+func Test_BatchTransport_ParentContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The batch operators keep the context of the source, used by the next operators
+	obs := FromChannel(make(chan Item), WithContext(ctx)).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithBatchTransport(16, nil))
+	assert.Equal(t, ctx, obs.(*ObservableImpl).parent)
+	single := obs.Reduce(func(_ context.Context, acc, elem interface{}) (interface{}, error) {
+		return elem, nil
+	}, WithBatchTransport(16, nil))
+	assert.Equal(t, ctx, single.(*OptionalSingleImpl).parent)
+}
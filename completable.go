@@ -0,0 +1,197 @@
+package rxgo
+
+import (
+	"context"
+	"time"
+)
+
+// Completable is an Iterable that either completes or fails, without emitting any item.
+// Observing a Completable emits at most one error item.
+type Completable interface {
+	Iterable
+	AndThen(next Completable, opts ...Option) Completable
+	Get(opts ...Option) error
+	OnErrorComplete(opts ...Option) Completable
+	Retry(count int, shouldRetry func(error) bool, opts ...Option) Completable
+	Run(opts ...Option) Disposed
+	Timeout(timeout Duration, opts ...Option) Completable
+	ToObservable() Observable
+	ToSingle(value interface{}, opts ...Option) Single
+}
+
+// CompletableImpl implements Completable.
+type CompletableImpl struct {
+	parent   context.Context
+	iterable Iterable
+}
+
+func completable(parent context.Context, f func(ctx context.Context, next chan Item, opts ...Option), opts ...Option) Completable {
+	return &CompletableImpl{
+		parent:   parent,
+		iterable: newOperatorIterable(parent, f, opts...),
+	}
+}
+
+// awaitCompletion waits for an Iterable to complete, discarding its items, and returns its first error.
+// If the context is canceled first, the context error is returned.
+func awaitCompletion(ctx context.Context, observe <-chan Item) error {
+	var err error
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case item, ok := <-observe:
+			if !ok {
+				return err
+			}
+			if item.Error() && err == nil {
+				err = item.E
+			}
+		}
+	}
+}
+
+// AndThen subscribes to the next Completable once the Completable completes.
+// If the Completable fails, the next Completable is not subscribed.
+func (c *CompletableImpl) AndThen(next Completable, opts ...Option) Completable {
+	return completable(c.parent, func(ctx context.Context, dst chan Item, opts ...Option) {
+		defer close(dst)
+		err := awaitCompletion(ctx, c.Observe(opts...))
+		if err == nil {
+			err = awaitCompletion(ctx, next.Observe(opts...))
+		}
+		if err != nil && ctx.Err() == nil {
+			Error(err).SendContext(ctx, dst)
+		}
+	}, opts...)
+}
+
+// Get waits for the Completable to complete and returns its error, nil if it completed successfully.
+// If the context is canceled first, the context error is returned.
+// This method is blocking.
+func (c *CompletableImpl) Get(opts ...Option) error {
+	option := parseOptions(opts...)
+	ctx := option.buildContext(c.parent)
+
+	return awaitCompletion(ctx, c.Observe(opts...))
+}
+
+// Observe observes a Completable by returning its channel.
+func (c *CompletableImpl) Observe(opts ...Option) <-chan Item {
+	return c.iterable.Observe(opts...)
+}
+
+// OnErrorComplete completes instead of failing if the Completable fails.
+func (c *CompletableImpl) OnErrorComplete(opts ...Option) Completable {
+	return completable(c.parent, func(ctx context.Context, dst chan Item, opts ...Option) {
+		defer close(dst)
+		_ = awaitCompletion(ctx, c.Observe(opts...))
+	}, opts...)
+}
+
+// Retry resubscribes to the Completable if it fails, at most count times and as long as shouldRetry returns true.
+func (c *CompletableImpl) Retry(count int, shouldRetry func(error) bool, opts ...Option) Completable {
+	return completable(c.parent, func(ctx context.Context, dst chan Item, opts ...Option) {
+		defer close(dst)
+		// Each observation is retried count times
+		remaining := count
+		for {
+			err := awaitCompletion(ctx, c.Observe(opts...))
+			if err == nil || ctx.Err() != nil {
+				return
+			}
+			remaining--
+			if remaining < 0 || !shouldRetry(err) {
+				Error(err).SendContext(ctx, dst)
+				return
+			}
+		}
+	}, opts...)
+}
+
+// Run creates an observer without consuming the emitted items.
+func (c *CompletableImpl) Run(opts ...Option) Disposed {
+	dispose := make(chan struct{})
+	option := parseOptions(opts...)
+	ctx := option.buildContext(c.parent)
+
+	go func() {
+		defer close(dispose)
+		_ = awaitCompletion(ctx, c.Observe(opts...))
+	}()
+
+	return dispose
+}
+
+// Timeout fails with a TimeoutError if the Completable does not complete within a timeout.
+// In this case, the Completable is canceled. The timeout must be positive.
+func (c *CompletableImpl) Timeout(timeout Duration, opts ...Option) Completable {
+	if timeout == nil || timeout.duration() <= 0 {
+		return &CompletableImpl{
+			parent:   c.parent,
+			iterable: Thrown(IllegalInputError{error: "timeout must be positive"}),
+		}
+	}
+
+	return completable(c.parent, func(ctx context.Context, dst chan Item, opts ...Option) {
+		defer close(dst)
+		sourceCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		done := make(chan error, 1)
+		observe := c.Observe(append(append([]Option{}, opts...), WithContext(sourceCtx))...)
+		go func() {
+			done <- awaitCompletion(sourceCtx, observe)
+		}()
+
+		timer := time.NewTimer(timeout.duration())
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+		case err := <-done:
+			if err != nil {
+				Error(err).SendContext(ctx, dst)
+			}
+		case <-timer.C:
+			Error(TimeoutError{error: "completable did not complete within " + timeout.duration().String()}).SendContext(ctx, dst)
+		}
+	}, opts...)
+}
+
+// ToObservable converts the Completable into an Observable emitting no item, or only the error of the Completable.
+func (c *CompletableImpl) ToObservable() Observable {
+	return &ObservableImpl{
+		parent:   c.parent,
+		iterable: c,
+	}
+}
+
+// ToSingle converts the Completable into a Single emitting a value once the Completable completes, or the
+// error of the Completable.
+func (c *CompletableImpl) ToSingle(value interface{}, opts ...Option) Single {
+	return single(c.parent, c, func() operator {
+		return &toSingleOperatorCompletable{value: value}
+	}, true, true, opts...)
+}
+
+type toSingleOperatorCompletable struct {
+	value   interface{}
+	errored bool
+}
+
+func (op *toSingleOperatorCompletable) next(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+func (op *toSingleOperatorCompletable) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	op.errored = true
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *toSingleOperatorCompletable) end(ctx context.Context, dst chan<- Item) {
+	if !op.errored {
+		Of(op.value).SendContext(ctx, dst)
+	}
+}
+
+func (op *toSingleOperatorCompletable) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
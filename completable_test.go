@@ -0,0 +1,177 @@
+package rxgo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func completableCounter(counter *int, errs ...error) Completable {
+	return FromAction(func(_ context.Context) error {
+		i := *counter
+		*counter++
+		if i < len(errs) {
+			return errs[i]
+		}
+		return nil
+	})
+}
+
+func Test_Completable_Get(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	assert.NoError(t, FromAction(func(_ context.Context) error {
+		return nil
+	}).Get())
+	assert.Equal(t, errFoo, FromAction(func(_ context.Context) error {
+		return errFoo
+	}).Get())
+}
+
+func Test_Completable_Get_ContextCanceled(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ch := make(chan Item)
+	defer close(ch)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := CompletableFromIterable(FromChannel(ch)).Get(WithContext(ctx))
+	assert.Equal(t, ctx.Err(), err)
+}
+
+func Test_Completable_AndThen(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var order []int
+	c := FromAction(func(_ context.Context) error {
+		order = append(order, 1)
+		return nil
+	}).AndThen(FromAction(func(_ context.Context) error {
+		order = append(order, 2)
+		return nil
+	}))
+	assert.NoError(t, c.Get())
+	assert.Equal(t, []int{1, 2}, order)
+}
+
+func Test_Completable_AndThen_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	c := FromAction(func(_ context.Context) error {
+		return errFoo
+	}).AndThen(completableCounter(&counter))
+	assert.Equal(t, errFoo, c.Get())
+	assert.Equal(t, 0, counter)
+}
+
+func Test_Completable_OnErrorComplete(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	assert.NoError(t, FromAction(func(_ context.Context) error {
+		return errFoo
+	}).OnErrorComplete().Get())
+}
+
+func Test_Completable_Retry(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	err := completableCounter(&counter, errFoo, errFoo).Retry(2, func(error) bool {
+		return true
+	}).Get()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, counter)
+}
+
+func Test_Completable_Retry_Exhausted(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	err := completableCounter(&counter, errFoo, errFoo, errFoo).Retry(1, func(error) bool {
+		return true
+	}).Get()
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, 2, counter)
+}
+
+func Test_Completable_Retry_Resubscribe(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	completable := FromAction(func(context.Context) error {
+		counter++
+		return errFoo
+	}).Retry(2, func(error) bool {
+		return true
+	})
+	// Each observation is retried the same number of times
+	assert.Equal(t, errFoo, completable.Get())
+	assert.Equal(t, 3, counter)
+	assert.Equal(t, errFoo, completable.Get())
+	assert.Equal(t, 6, counter)
+}
+
+func Test_Completable_Retry_ShouldNotRetry(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	err := completableCounter(&counter, errFoo).Retry(3, func(err error) bool {
+		return err != errFoo
+	}).Get()
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, 1, counter)
+}
+
+func Test_Completable_Run(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	<-completableCounter(&counter).Run()
+	assert.Equal(t, 1, counter)
+}
+
+func Test_Completable_Timeout(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	err := FromAction(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}).Timeout(WithDuration(10 * time.Millisecond)).Get()
+	assert.True(t, errors.As(err, &TimeoutError{}))
+}
+
+func Test_Completable_Timeout_Completed(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	err := FromAction(func(_ context.Context) error {
+		return errFoo
+	}).Timeout(WithDuration(time.Minute)).Get()
+	assert.Equal(t, errFoo, err)
+}
+
+func Test_Completable_Timeout_InvalidTimeout(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	for _, timeout := range []Duration{nil, WithDuration(0), WithDuration(-time.Second)} {
+		err := FromAction(func(_ context.Context) error {
+			return nil
+		}).Timeout(timeout).Get()
+		assert.True(t, errors.As(err, &IllegalInputError{}))
+	}
+}
+
+func Test_Completable_ToObservable(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, FromAction(func(_ context.Context) error {
+		return nil
+	}).ToObservable(), IsEmpty(), HasNoError())
+	Assert(ctx, t, FromAction(func(_ context.Context) error {
+		return errFoo
+	}).ToObservable(), IsEmpty(), HasError(errFoo))
+}
+
+func Test_Completable_ToSingle(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, FromAction(func(_ context.Context) error {
+		return nil
+	}).ToSingle(1), HasItem(1))
+	Assert(ctx, t, FromAction(func(_ context.Context) error {
+		return errFoo
+	}).ToSingle(1), IsEmpty(), HasError(errFoo))
+}
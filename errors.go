@@ -1,5 +1,11 @@
 package rxgo
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 // IllegalInputError is triggered when the observable receives an illegal input.
 type IllegalInputError struct {
 	error string
@@ -17,3 +23,103 @@ type IndexOutOfBoundError struct {
 func (e IndexOutOfBoundError) Error() string {
 	return "index out of bound: " + e.error
 }
+
+// TimeoutError is triggered when an operation does not complete within a timeout.
+type TimeoutError struct {
+	error string
+}
+
+func (e TimeoutError) Error() string {
+	return "timeout: " + e.error
+}
+
+// BufferOverflowError is triggered when a backpressure buffer exceeds its capacity.
+type BufferOverflowError struct {
+	error string
+}
+
+func (e BufferOverflowError) Error() string {
+	return "buffer overflow: " + e.error
+}
+
+// OperatorError is triggered when a named operator fails to process an item.
+// It wraps the original cause along with the stage name, the operator and the input value.
+type OperatorError struct {
+	// Name is the stage name configured using WithName
+	Name string
+	// Operator is the kind of operator (e.g. Map)
+	Operator string
+	// Value is the input value that produced the error
+	Value interface{}
+	// Err is the original cause
+	Err error
+}
+
+func (e OperatorError) Error() string {
+	return fmt.Sprintf("%s: %s(%v): %v", e.Name, e.Operator, e.Value, e.Err)
+}
+
+// Unwrap returns the original cause.
+func (e OperatorError) Unwrap() error {
+	return e.Err
+}
+
+func wrapOperatorError(name, operator string, value interface{}, err error) error {
+	if name == "" {
+		return err
+	}
+	return OperatorError{
+		Name:     name,
+		Operator: operator,
+		Value:    value,
+		Err:      err,
+	}
+}
+
+// CompositeError is triggered when several errors are aggregated into a single one.
+// errors.Is and errors.As are matched against each cause.
+type CompositeError struct {
+	// Errs are the aggregated causes
+	Errs []error
+}
+
+func (e CompositeError) Error() string {
+	s := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		s[i] = err.Error()
+	}
+	return fmt.Sprintf("composite error: %d errors: [%s]", len(e.Errs), strings.Join(s, "; "))
+}
+
+// Is reports whether any of the causes matches the target.
+func (e CompositeError) Is(target error) bool {
+	for _, err := range e.Errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first cause that matches the target, and if so, sets target to that cause.
+func (e CompositeError) As(target interface{}) bool {
+	for _, err := range e.Errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// compositeError returns nil if there is no error, the error itself if there is only one,
+// otherwise a CompositeError.
+func compositeError(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return CompositeError{Errs: errs}
+	}
+}
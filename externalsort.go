@@ -0,0 +1,269 @@
+package rxgo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/emirpasic/gods/trees/binaryheap"
+)
+
+// sortMaxFanIn is the maximum number of runs merged at once, bounding the number of open files.
+const sortMaxFanIn = 64
+
+// sortRun is a sorted run of items spilled to a temporary file.
+// Each item is encoded by a codec and prefixed by its length. The file is only open while the run is merged.
+type sortRun struct {
+	path   string
+	file   *os.File
+	reader *bufio.Reader
+}
+
+// sortRunWriter writes the items of a run, in order.
+type sortRunWriter struct {
+	file      *os.File
+	writer    *bufio.Writer
+	codec     Codec
+	lengthBuf []byte
+}
+
+func newSortRunWriter(dir string, codec Codec) (*sortRunWriter, error) {
+	f, err := ioutil.TempFile(dir, "rxgo-sort-")
+	if err != nil {
+		return nil, err
+	}
+	return &sortRunWriter{
+		file:      f,
+		writer:    bufio.NewWriter(f),
+		codec:     codec,
+		lengthBuf: make([]byte, binary.MaxVarintLen64),
+	}, nil
+}
+
+func (w *sortRunWriter) write(item interface{}) error {
+	b, err := w.codec.Marshal(item)
+	if err != nil {
+		return err
+	}
+	n := binary.PutUvarint(w.lengthBuf, uint64(len(b)))
+	if _, err := w.writer.Write(w.lengthBuf[:n]); err != nil {
+		return err
+	}
+	_, err = w.writer.Write(b)
+	return err
+}
+
+// close flushes and closes the file of the run.
+func (w *sortRunWriter) close() (*sortRun, error) {
+	if err := w.writer.Flush(); err != nil {
+		w.abort()
+		return nil, err
+	}
+	if err := w.file.Close(); err != nil {
+		_ = os.Remove(w.file.Name())
+		return nil, err
+	}
+	return &sortRun{path: w.file.Name()}, nil
+}
+
+// abort closes and removes the file of the run.
+func (w *sortRunWriter) abort() {
+	_ = w.file.Close()
+	_ = os.Remove(w.file.Name())
+}
+
+func writeSortRun(dir string, items []interface{}, codec Codec) (*sortRun, error) {
+	w, err := newSortRunWriter(dir, codec)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if err := w.write(item); err != nil {
+			w.abort()
+			return nil, err
+		}
+	}
+	return w.close()
+}
+
+// mergeSortRuns merges runs into a single run. The merged runs are removed.
+func mergeSortRuns(dir string, runs []*sortRun, comparator Comparator, codec Codec) (*sortRun, error) {
+	defer func() {
+		for _, run := range runs {
+			run.remove()
+		}
+	}()
+	merger, err := newSortMerger(runs, nil, comparator, codec)
+	if err != nil {
+		return nil, err
+	}
+	w, err := newSortRunWriter(dir, codec)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		v, ok, err := merger.next()
+		if err != nil {
+			w.abort()
+			return nil, err
+		}
+		if !ok {
+			return w.close()
+		}
+		if err := w.write(v); err != nil {
+			w.abort()
+			return nil, err
+		}
+	}
+}
+
+// reduceSortRuns merges the runs in passes, at most fanIn runs at a time, until at most fanIn runs remain.
+// Only consecutive runs are merged together so that the sort remains stable. In case of an error, every run is
+// removed.
+func reduceSortRuns(dir string, runs []*sortRun, fanIn int, comparator Comparator, codec Codec) ([]*sortRun, error) {
+	for len(runs) > fanIn {
+		merged := make([]*sortRun, 0, (len(runs)+fanIn-1)/fanIn)
+		for i := 0; i < len(runs); i += fanIn {
+			end := i + fanIn
+			if end > len(runs) {
+				end = len(runs)
+			}
+			if end-i == 1 {
+				merged = append(merged, runs[i])
+				continue
+			}
+			run, err := mergeSortRuns(dir, runs[i:end], comparator, codec)
+			if err != nil {
+				for _, run := range merged {
+					run.remove()
+				}
+				for _, run := range runs[end:] {
+					run.remove()
+				}
+				return nil, err
+			}
+			merged = append(merged, run)
+		}
+		runs = merged
+	}
+	return runs, nil
+}
+
+func (r *sortRun) open() error {
+	f, err := os.Open(r.path)
+	if err != nil {
+		return err
+	}
+	r.file = f
+	r.reader = bufio.NewReader(f)
+	return nil
+}
+
+// read returns the next item of the run, false once the run is exhausted.
+func (r *sortRun) read(codec Codec) (interface{}, bool, error) {
+	length, err := binary.ReadUvarint(r.reader)
+	if err == io.EOF {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(r.reader, b); err != nil {
+		return nil, false, err
+	}
+	v, err := codec.Unmarshal(b)
+	if err != nil {
+		return nil, false, err
+	}
+	return v, true, nil
+}
+
+func (r *sortRun) remove() {
+	if r.file != nil {
+		_ = r.file.Close()
+		r.file = nil
+	}
+	_ = os.Remove(r.path)
+}
+
+// sortMerger merges sorted sources, a source being either a spilled run or an in-memory slice.
+// The ties are resolved by the source index so that the merge is stable.
+type sortMerger struct {
+	runs   []*sortRun
+	memory []interface{}
+	codec  Codec
+	heap   *binaryheap.Heap
+}
+
+type sortMergerEntry struct {
+	value  interface{}
+	source int
+}
+
+func newSortMerger(runs []*sortRun, memory []interface{}, comparator Comparator, codec Codec) (*sortMerger, error) {
+	m := &sortMerger{
+		runs:   runs,
+		memory: memory,
+		codec:  codec,
+		heap: binaryheap.NewWith(func(a, b interface{}) int {
+			e1, e2 := a.(sortMergerEntry), b.(sortMergerEntry)
+			if c := comparator(e1.value, e2.value); c != 0 {
+				return c
+			}
+			return e1.source - e2.source
+		}),
+	}
+	for _, run := range runs {
+		if err := run.open(); err != nil {
+			return nil, err
+		}
+	}
+	for i := 0; i <= len(runs); i++ {
+		if err := m.pull(i); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// pull pushes the next item of a source into the heap.
+func (m *sortMerger) pull(source int) error {
+	if source == len(m.runs) {
+		if len(m.memory) > 0 {
+			m.heap.Push(sortMergerEntry{value: m.memory[0], source: source})
+			m.memory = m.memory[1:]
+		}
+		return nil
+	}
+	v, ok, err := m.runs[source].read(m.codec)
+	if err != nil {
+		return err
+	}
+	if ok {
+		m.heap.Push(sortMergerEntry{value: v, source: source})
+	}
+	return nil
+}
+
+// next returns the smallest remaining item, false once every source is exhausted.
+func (m *sortMerger) next() (interface{}, bool, error) {
+	v, ok := m.heap.Pop()
+	if !ok {
+		return nil, false, nil
+	}
+	e := v.(sortMergerEntry)
+	if err := m.pull(e.source); err != nil {
+		return nil, false, err
+	}
+	return e.value, true, nil
+}
+
+func sortItems(items []interface{}, comparator Comparator) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return comparator(items[i], items[j]) < 0
+	})
+}
@@ -0,0 +1,60 @@
+package rxgo
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReduceSortRuns(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rxgo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// 10 runs of 3 items: "a0", "b0", "c0", then "a1", "b1", "c1", etc.
+	runs := make([]*sortRun, 0)
+	expected := make([]interface{}, 0)
+	for _, prefix := range []string{"a", "b", "c"} {
+		for i := 0; i < 10; i++ {
+			expected = append(expected, fmt.Sprintf("%s%d", prefix, i))
+		}
+	}
+	for i := 0; i < 10; i++ {
+		run, err := writeSortRun(dir, []interface{}{
+			fmt.Sprintf("a%d", i), fmt.Sprintf("b%d", i), fmt.Sprintf("c%d", i),
+		}, stringCodec{})
+		assert.NoError(t, err)
+		runs = append(runs, run)
+	}
+
+	runs, err = reduceSortRuns(dir, runs, 3, compareFirstByte, stringCodec{})
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(runs), 3)
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, len(runs))
+
+	// The merge is stable: the items of a run come before the equal items of the next runs
+	merger, err := newSortMerger(runs, nil, compareFirstByte, stringCodec{})
+	assert.NoError(t, err)
+	items := make([]interface{}, 0)
+	for {
+		v, ok, err := merger.next()
+		assert.NoError(t, err)
+		if !ok {
+			break
+		}
+		items = append(items, v)
+	}
+	assert.Equal(t, expected, items)
+
+	for _, run := range runs {
+		run.remove()
+	}
+	files, err = ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
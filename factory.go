@@ -8,6 +8,27 @@ import (
 	"time"
 )
 
+// AllSettled subscribes to several Singles concurrently and emits a []Item holding the item or the error of
+// each Single, in the order of the Singles, once they have all completed.
+func AllSettled(singles []Single, opts ...Option) Single {
+	return &SingleImpl{
+		iterable: newOperatorIterable(nil, func(ctx context.Context, next chan Item, opts ...Option) {
+			defer close(next)
+			results := settleSingles(ctx, singles, opts...)
+			items := make([]Item, len(singles))
+			for range singles {
+				select {
+				case <-ctx.Done():
+					return
+				case result := <-results:
+					items[result.index] = result.item
+				}
+			}
+			Of(items).SendContext(ctx, next)
+		}, opts...),
+	}
+}
+
 // Amb takes several Observables, emit all of the items from only the first of these Observables
 // to emit an item or notification.
 func Amb(observables []Observable, opts ...Option) Observable {
@@ -61,6 +82,172 @@ func Amb(observables []Observable, opts ...Option) Observable {
 	}
 }
 
+// Any subscribes to several Singles concurrently and emits the item of the first Single succeeding, canceling
+// the other Singles. If every Single fails, the errors are emitted as a CompositeError.
+func Any(singles []Single, opts ...Option) Single {
+	if len(singles) == 0 {
+		return &SingleImpl{iterable: Thrown(IllegalInputError{error: "singles must not be empty"})}
+	}
+	return &SingleImpl{
+		iterable: newOperatorIterable(nil, func(ctx context.Context, next chan Item, opts ...Option) {
+			defer close(next)
+			anyCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			results := settleSingles(anyCtx, singles, opts...)
+			errs := make([]error, len(singles))
+			for range singles {
+				select {
+				case <-ctx.Done():
+					return
+				case result := <-results:
+					if result.ok && !result.item.Error() {
+						cancel()
+						result.item.SendContext(ctx, next)
+						return
+					}
+					if result.ok {
+						errs[result.index] = result.item.E
+					}
+				}
+			}
+			causes := make([]error, 0, len(errs))
+			for _, err := range errs {
+				if err != nil {
+					causes = append(causes, err)
+				}
+			}
+			if len(causes) > 0 {
+				Error(CompositeError{Errs: causes}).SendContext(ctx, next)
+			}
+		}, opts...),
+	}
+}
+
+// CoGroupedItems is the item type emitted by the CoGroup operator.
+type CoGroupedItems struct {
+	// Key is the co-grouping key
+	Key interface{}
+	// Items holds, for each input Observable, its items having the key, in arrival order
+	Items [][]interface{}
+}
+
+// CoGroup groups the items emitted by several Observables by key, within tumbling time windows.
+// The key of an item is computed by the key selector having the same index as its Observable, the keys
+// being comparable. At the end of each window, a CoGroupedItems is emitted per key seen during the window,
+// in the order the keys were first seen.
+// The current window is emitted when every Observable completes. When an Observable encounters an error, the
+// error is emitted and, unless ContinueOnError is used, the current window is discarded.
+// The window must be positive.
+func CoGroup(keySelectors []func(interface{}) interface{}, window Duration, observables []Observable, opts ...Option) Observable {
+	if len(keySelectors) != len(observables) {
+		return Thrown(IllegalInputError{error: "a key selector is required for each observable"})
+	}
+	if window == nil || window.duration() <= 0 {
+		return Thrown(IllegalInputError{error: "window must be positive"})
+	}
+
+	option := parseOptions(opts...)
+	ctx, cancel := context.WithCancel(option.buildContext(emptyContext))
+	next := option.buildChannel()
+	type indexedItem struct {
+		index int
+		item  Item
+	}
+	merged := make(chan indexedItem)
+	wg := sync.WaitGroup{}
+	wg.Add(len(observables))
+
+	for i, o := range observables {
+		go func(i int, o Observable) {
+			defer wg.Done()
+			observe := o.Observe(opts...)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-observe:
+					if !ok {
+						return
+					}
+					select {
+					case <-ctx.Done():
+						return
+					case merged <- indexedItem{index: i, item: item}:
+					}
+				}
+			}
+		}(i, o)
+	}
+
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+
+	go func() {
+		defer close(next)
+		defer cancel()
+		duration := window.duration()
+		groups := make(map[interface{}]*CoGroupedItems)
+		keys := make([]interface{}, 0)
+
+		flush := func() bool {
+			for _, key := range keys {
+				if !Of(*groups[key]).SendContext(ctx, next) {
+					return false
+				}
+			}
+			groups = make(map[interface{}]*CoGroupedItems)
+			keys = make([]interface{}, 0)
+			return true
+		}
+
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				if !flush() {
+					return
+				}
+				timer.Reset(duration)
+			case i, ok := <-merged:
+				if !ok {
+					flush()
+					return
+				}
+				if i.item.Error() {
+					if !i.item.SendContext(ctx, next) {
+						return
+					}
+					if option.getErrorStrategy() == StopOnError {
+						return
+					}
+					continue
+				}
+				key := keySelectors[i.index](i.item.V)
+				group, exists := groups[key]
+				if !exists {
+					group = &CoGroupedItems{Key: key, Items: make([][]interface{}, len(observables))}
+					for j := range group.Items {
+						group.Items[j] = make([]interface{}, 0)
+					}
+					groups[key] = group
+					keys = append(keys, key)
+				}
+				group.Items[i.index] = append(group.Items[i.index], i.item.V)
+			}
+		}
+	}()
+
+	return &ObservableImpl{
+		iterable: newChannelIterable(next),
+	}
+}
+
 // CombineLatest combines the latest item emitted by each Observable via a specified function
 // and emit items based on the results of this function.
 func CombineLatest(f FuncN, observables []Observable, opts ...Option) Observable {
@@ -127,6 +314,17 @@ func CombineLatest(f FuncN, observables []Observable, opts ...Option) Observable
 	}
 }
 
+// CompletableFromIterable creates a Completable completing once an Iterable completes, discarding its items.
+// If the Iterable emits an error, the Completable fails with the first error.
+func CompletableFromIterable(iterable Iterable, opts ...Option) Completable {
+	return completable(nil, func(ctx context.Context, next chan Item, opts ...Option) {
+		defer close(next)
+		if err := awaitCompletion(ctx, iterable.Observe(opts...)); err != nil && ctx.Err() == nil {
+			Error(err).SendContext(ctx, next)
+		}
+	}, opts...)
+}
+
 // Concat emits the emissions from two or more Observables without interleaving them.
 func Concat(observables []Observable, opts ...Option) Observable {
 	option := parseOptions(opts...)
@@ -160,6 +358,66 @@ func Concat(observables []Observable, opts ...Option) Observable {
 	}
 }
 
+// ConcatCompletables subscribes to several Completables one after the other and completes once they all complete.
+// It fails with the first error, without subscribing to the remaining Completables.
+func ConcatCompletables(completables []Completable, opts ...Option) Completable {
+	return completable(nil, func(ctx context.Context, next chan Item, opts ...Option) {
+		defer close(next)
+		for _, c := range completables {
+			err := awaitCompletion(ctx, c.Observe(opts...))
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				Error(err).SendContext(ctx, next)
+				return
+			}
+		}
+	}, opts...)
+}
+
+// ConcatDelayError emits the emissions from two or more Observables without interleaving them.
+// Contrary to Concat, an error does not stop the sequence: every Observable is consumed and the errors
+// are emitted once all the Observables complete. If several errors were raised, they are aggregated into
+// a CompositeError.
+func ConcatDelayError(observables []Observable, opts ...Option) Observable {
+	option := parseOptions(opts...)
+	ctx := option.buildContext(emptyContext)
+	next := option.buildChannel()
+
+	go func() {
+		defer close(next)
+		errs := make([]error, 0)
+		for _, obs := range observables {
+			observe := obs.Observe(opts...)
+		loop:
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-observe:
+					if !ok {
+						break loop
+					}
+					if item.Error() {
+						errs = append(errs, item.E)
+						continue
+					}
+					if !item.SendContext(ctx, next) {
+						return
+					}
+				}
+			}
+		}
+		if err := compositeError(errs); err != nil {
+			Error(err).SendContext(ctx, next)
+		}
+	}()
+	return &ObservableImpl{
+		iterable: newChannelIterable(next),
+	}
+}
+
 // Create creates an Observable from scratch by calling observer methods programmatically.
 func Create(f []Producer, opts ...Option) Observable {
 	return &ObservableImpl{
@@ -167,6 +425,25 @@ func Create(f []Producer, opts ...Option) Observable {
 	}
 }
 
+// CreateFlowable creates a Flowable from a producer emitting items according to the demand.
+// The producer is executed at each subscription and the Flowable completes once the producer returns.
+func CreateFlowable(producer FlowableProducer, opts ...Option) Flowable {
+	return &FlowableImpl{
+		subscribe: func(propagatedOptions ...Option) (<-chan Item, Subscription) {
+			option := parseOptions(append(opts, propagatedOptions...)...)
+			next := option.buildChannel()
+			sub := newSubscription(option.buildContext(emptyContext))
+
+			go func() {
+				defer close(next)
+				defer sub.Cancel()
+				producer(sub.ctx, &flowableEmitter{sub: sub, next: next})
+			}()
+			return next, sub
+		},
+	}
+}
+
 // Defer does not create the Observable until the observer subscribes,
 // and creates a fresh Observable for each observer.
 func Defer(f []Producer, opts ...Option) Observable {
@@ -184,6 +461,33 @@ func Empty() Observable {
 	}
 }
 
+// FromAction creates a Completable from an action, completing once the action returns.
+// If the action returns an error, the Completable fails with it. The action is executed at each subscription.
+func FromAction(action func(ctx context.Context) error, opts ...Option) Completable {
+	return completable(nil, func(ctx context.Context, next chan Item, _ ...Option) {
+		defer close(next)
+		if err := action(ctx); err != nil {
+			Error(err).SendContext(ctx, next)
+		}
+	}, opts...)
+}
+
+// FromFunc creates a Single from a function, emitting its result or its error.
+// The function is executed at each subscription, with a context canceled once the subscription is canceled.
+func FromFunc(f func(ctx context.Context) (interface{}, error), opts ...Option) Single {
+	return &SingleImpl{
+		iterable: newOperatorIterable(nil, func(ctx context.Context, next chan Item, _ ...Option) {
+			defer close(next)
+			v, err := f(ctx)
+			if err != nil {
+				Error(err).SendContext(ctx, next)
+				return
+			}
+			Of(v).SendContext(ctx, next)
+		}, opts...),
+	}
+}
+
 // FromChannel creates a cold observable from a channel.
 func FromChannel(next <-chan Item, opts ...Option) Observable {
 	option := parseOptions(opts...)
@@ -199,7 +503,7 @@ func FromEventSource(next <-chan Item, opts ...Option) Observable {
 	option := parseOptions(opts...)
 
 	return &ObservableImpl{
-		iterable: newEventSourceIterable(option.buildContext(emptyContext), next, option.getBackPressureStrategy()),
+		iterable: newEventSourceIterable(option.buildContext(emptyContext), next, option.getBackPressureStrategy(), option.getOnDrop()),
 	}
 }
 
@@ -226,7 +530,7 @@ func Interval(interval Duration, opts ...Option) Observable {
 		}
 	}()
 	return &ObservableImpl{
-		iterable: newEventSourceIterable(ctx, next, option.getBackPressureStrategy()),
+		iterable: newEventSourceIterable(ctx, next, option.getBackPressureStrategy(), option.getOnDrop()),
 	}
 }
 
@@ -287,6 +591,90 @@ func Merge(observables []Observable, opts ...Option) Observable {
 	}
 }
 
+// MergeCompletables subscribes to several Completables concurrently and completes once they all complete.
+// It fails with the first error, canceling the other Completables.
+func MergeCompletables(completables []Completable, opts ...Option) Completable {
+	return completable(nil, func(ctx context.Context, next chan Item, opts ...Option) {
+		defer close(next)
+		mergeCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		errs := make(chan error, len(completables))
+		observeOpts := append(append([]Option{}, opts...), WithContext(mergeCtx))
+		for _, c := range completables {
+			observe := c.Observe(observeOpts...)
+			go func() {
+				errs <- awaitCompletion(mergeCtx, observe)
+			}()
+		}
+		for range completables {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errs:
+				if err != nil {
+					cancel()
+					Error(err).SendContext(ctx, next)
+					return
+				}
+			}
+		}
+	}, opts...)
+}
+
+// MergeDelayError combines multiple Observables into one by merging their emissions.
+// Contrary to Merge, an error does not stop the forwarding of an Observable: every Observable is consumed
+// and the errors are emitted once all the Observables complete. If several errors were raised, they are
+// aggregated into a CompositeError.
+func MergeDelayError(observables []Observable, opts ...Option) Observable {
+	option := parseOptions(opts...)
+	ctx := option.buildContext(emptyContext)
+	next := option.buildChannel()
+	wg := sync.WaitGroup{}
+	wg.Add(len(observables))
+	mutex := sync.Mutex{}
+	errs := make([]error, 0)
+
+	f := func(o Observable) {
+		defer wg.Done()
+		observe := o.Observe(opts...)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				if item.Error() {
+					mutex.Lock()
+					errs = append(errs, item.E)
+					mutex.Unlock()
+					continue
+				}
+				if !item.SendContext(ctx, next) {
+					return
+				}
+			}
+		}
+	}
+
+	for _, o := range observables {
+		go f(o)
+	}
+
+	go func() {
+		defer close(next)
+		wg.Wait()
+		if err := compositeError(errs); err != nil {
+			Error(err).SendContext(ctx, next)
+		}
+	}()
+	return &ObservableImpl{
+		iterable: newChannelIterable(next),
+	}
+}
+
 // Never creates an Observable that emits no items and does not terminate.
 func Never() Observable {
 	next := make(chan Item)
@@ -361,3 +749,73 @@ func Timer(d Duration, opts ...Option) Observable {
 		iterable: newChannelIterable(next),
 	}
 }
+
+// ZipSingles subscribes to several Singles concurrently and emits the result of a zipper function applied to
+// their items, in the order of the Singles.
+// If a Single fails, the error is emitted and the other Singles are canceled.
+func ZipSingles(singles []Single, zipper FuncN, opts ...Option) Single {
+	return &SingleImpl{
+		iterable: newOperatorIterable(nil, func(ctx context.Context, next chan Item, opts ...Option) {
+			defer close(next)
+			zipCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			results := settleSingles(zipCtx, singles, opts...)
+			values := make([]interface{}, len(singles))
+			for range singles {
+				select {
+				case <-ctx.Done():
+					return
+				case result := <-results:
+					if !result.ok {
+						// A Single completed without item
+						return
+					}
+					if result.item.Error() {
+						cancel()
+						result.item.SendContext(ctx, next)
+						return
+					}
+					values[result.index] = result.item.V
+				}
+			}
+			Of(zipper(values...)).SendContext(ctx, next)
+		}, opts...),
+	}
+}
+
+type settledSingle struct {
+	index int
+	item  Item
+	ok    bool
+}
+
+// settleSingles subscribes to several Singles concurrently and sends the result of each Single once it completes.
+// The Singles are canceled with the context. The channel is buffered so that no goroutine remains blocked.
+func settleSingles(ctx context.Context, singles []Single, opts ...Option) <-chan settledSingle {
+	results := make(chan settledSingle, len(singles))
+	observeOpts := append(append([]Option{}, opts...), WithContext(ctx))
+	for i, s := range singles {
+		i, observe := i, s.Observe(observeOpts...)
+		go func() {
+			result := settledSingle{index: i}
+			for {
+				select {
+				case <-ctx.Done():
+					results <- result
+					return
+				case item, ok := <-observe:
+					if !ok {
+						results <- result
+						return
+					}
+					if !result.ok || (item.Error() && !result.item.Error()) {
+						result.item = item
+						result.ok = true
+					}
+				}
+			}
+		}()
+	}
+	return results
+}
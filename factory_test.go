@@ -3,6 +3,7 @@ package rxgo
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -29,6 +30,14 @@ func collect(ctx context.Context, ch <-chan Item) ([]interface{}, error) {
 	}
 }
 
+func Test_AllSettled(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	single := AllSettled([]Single{JustItem(1), JustItem(errFoo), JustItem(3)})
+	Assert(ctx, t, single, HasItem([]Item{Of(1), Error(errFoo), Of(3)}))
+}
+
 func Test_Amb1(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -45,6 +54,121 @@ func Test_Amb2(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2, 3))
 }
 
+func coGroupKey(i interface{}) interface{} {
+	return i.(string)[:1]
+}
+
+func Test_Any(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// The first success cancels the other Singles
+	single := Any([]Single{
+		JustItem(errFoo),
+		FromFunc(func(ctx context.Context) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}),
+		JustItem(1),
+	})
+	Assert(ctx, t, single, HasItem(1), HasNoError())
+}
+
+func Test_Any_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := Any([]Single{JustItem(errFoo), JustItem(errBar)}).Await(ctx)
+	assert.Equal(t, CompositeError{Errs: []error{errFoo, errBar}}, err)
+}
+
+func Test_Any_InputError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, Any(nil), HasAnError())
+}
+
+func Test_CoGroup(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := CoGroup([]func(interface{}) interface{}{coGroupKey, coGroupKey, coGroupKey}, WithDuration(time.Minute),
+		[]Observable{
+			testObservable(ctx, "1/order", "2/order"),
+			testObservable(ctx, "1/shipment"),
+			testObservable(ctx, "2/return1", "2/return2"),
+		})
+	Assert(ctx, t, obs, CustomPredicate(func(items []interface{}) error {
+		assert.ElementsMatch(t, []interface{}{
+			CoGroupedItems{Key: "1", Items: [][]interface{}{{"1/order"}, {"1/shipment"}, {}}},
+			CoGroupedItems{Key: "2", Items: [][]interface{}{{"2/order"}, {}, {"2/return1", "2/return2"}}},
+		}, items)
+		return nil
+	}), HasNoError())
+}
+
+func Test_CoGroup_Window(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	orders := make(chan Item)
+	shipments := make(chan Item)
+	obs := CoGroup([]func(interface{}) interface{}{coGroupKey, coGroupKey}, WithDuration(30*time.Millisecond),
+		[]Observable{FromChannel(orders), FromChannel(shipments)})
+	go func() {
+		orders <- Of("1/order")
+		time.Sleep(80 * time.Millisecond)
+		shipments <- Of("1/shipment")
+		close(orders)
+		close(shipments)
+	}()
+	Assert(ctx, t, obs, HasItems(
+		CoGroupedItems{Key: "1", Items: [][]interface{}{{"1/order"}, {}}},
+		CoGroupedItems{Key: "1", Items: [][]interface{}{{}, {"1/shipment"}}},
+	))
+}
+
+func Test_CoGroup_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := CoGroup([]func(interface{}) interface{}{coGroupKey, coGroupKey}, WithDuration(time.Minute),
+		[]Observable{testObservable(ctx, "1/order"), testObservable(ctx, errFoo)})
+	// The current window is discarded
+	Assert(ctx, t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_CoGroup_Error_ContinueOnError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := CoGroup([]func(interface{}) interface{}{coGroupKey, coGroupKey}, WithDuration(time.Minute),
+		[]Observable{testObservable(ctx, "1/order"), testObservable(ctx, errFoo)},
+		WithErrorStrategy(ContinueOnError))
+	Assert(ctx, t, obs, HasItems(CoGroupedItems{Key: "1", Items: [][]interface{}{{"1/order"}, {}}}),
+		HasError(errFoo))
+}
+
+func Test_CoGroup_InputError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := CoGroup([]func(interface{}) interface{}{coGroupKey}, WithDuration(time.Minute),
+		[]Observable{Empty(), Empty()})
+	Assert(ctx, t, obs, HasAnError())
+}
+
+func Test_CoGroup_InvalidWindow(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, window := range []Duration{nil, WithDuration(0), WithDuration(-time.Second)} {
+		obs := CoGroup([]func(interface{}) interface{}{coGroupKey}, window, []Observable{Empty()})
+		Assert(ctx, t, obs, IsEmpty(), HasError(IllegalInputError{error: "window must be positive"}))
+	}
+}
+
 func Test_CombineLatest(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -90,6 +214,13 @@ func Test_CombineLatest_Error(t *testing.T) {
 	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
 }
 
+func Test_CompletableFromIterable(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	assert.NoError(t, CompletableFromIterable(Just(1, 2, 3)()).Get())
+	assert.Equal(t, errFoo, CompletableFromIterable(Just(1, errFoo, 3)()).Get())
+	assert.Equal(t, errFoo, CompletableFromIterable(JustItem(errFoo)).Get())
+}
+
 func Test_Concat_SingleObservable(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -131,6 +262,52 @@ func Test_Concat_OneEmptyObservable(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2, 3))
 }
 
+func Test_ConcatCompletables(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var order []int
+	action := func(i int) Completable {
+		return FromAction(func(_ context.Context) error {
+			order = append(order, i)
+			return nil
+		})
+	}
+	assert.NoError(t, ConcatCompletables([]Completable{action(1), action(2), action(3)}).Get())
+	assert.Equal(t, []int{1, 2, 3}, order)
+}
+
+func Test_ConcatCompletables_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	c := ConcatCompletables([]Completable{completableCounter(&counter), completableCounter(&counter, errFoo, errFoo),
+		completableCounter(&counter)})
+	assert.Equal(t, errFoo, c.Get())
+	assert.Equal(t, 2, counter)
+}
+
+func Test_ConcatDelayError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := ConcatDelayError([]Observable{testObservable(ctx, 1, errFoo, 2), testObservable(ctx, 3, errBar, 4)})
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3, 4), HasError(CompositeError{Errs: []error{errFoo, errBar}}))
+}
+
+func Test_ConcatDelayError_SingleError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := ConcatDelayError([]Observable{testObservable(ctx, 1, errFoo), testObservable(ctx, 2, 3)})
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasError(errFoo))
+}
+
+func Test_ConcatDelayError_NoError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := ConcatDelayError([]Observable{testObservable(ctx, 1, 2), testObservable(ctx, 3)})
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+}
+
 func Test_Create(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	obs := Create([]Producer{func(ctx context.Context, next chan<- Item) {
@@ -273,6 +450,56 @@ func Test_Empty(t *testing.T) {
 	Assert(context.Background(), t, obs, IsEmpty())
 }
 
+func Test_FromAction(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	c := completableCounter(&counter, errFoo)
+	// The action is executed at each subscription
+	assert.Equal(t, errFoo, c.Get())
+	assert.NoError(t, c.Get())
+	assert.Equal(t, 2, counter)
+}
+
+func Test_FromFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	single := FromFunc(func(_ context.Context) (interface{}, error) {
+		counter++
+		return counter, nil
+	})
+	// The function is executed at each subscription
+	v, err := single.Await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	v, err = single.Await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+}
+
+func Test_FromFunc_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	single := FromFunc(func(_ context.Context) (interface{}, error) {
+		return nil, errFoo
+	})
+	Assert(ctx, t, single, IsEmpty(), HasError(errFoo))
+}
+
+func Test_FromFunc_ContextCanceled(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	canceled := make(chan struct{})
+	_, err := FromFunc(func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		close(canceled)
+		return nil, ctx.Err()
+	}).Await(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	<-canceled
+}
+
 func Test_FromChannel(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ch := make(chan Item)
@@ -355,6 +582,26 @@ func Test_FromEventSource_Drop(t *testing.T) {
 	}))
 }
 
+func Test_FromEventSource_Latest(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	next := make(chan Item)
+	var dropped int32
+	obs := FromEventSource(next, WithBackPressureStrategy(Latest), WithOnDrop(func(Item) {
+		atomic.AddInt32(&dropped, 1)
+	}))
+	observe := obs.Observe()
+
+	for i := 0; i < 5; i++ {
+		next <- Of(i)
+	}
+	close(next)
+	awaitDrops(t, &dropped, 4)
+
+	items, err := collect(context.Background(), observe)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{4}, items)
+}
+
 // FIXME
 //func Test_Interval(t *testing.T) {
 //	defer goleak.VerifyNone(t)
@@ -432,6 +679,68 @@ func Test_Merge(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItemsNoOrder(1, 2, 3, 4))
 }
 
+func Test_MergeCompletables(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var counter int32
+	action := FromAction(func(_ context.Context) error {
+		atomic.AddInt32(&counter, 1)
+		return nil
+	})
+	assert.NoError(t, MergeCompletables([]Completable{action, action, action}).Get())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&counter))
+}
+
+func Test_MergeCompletables_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	// The error cancels the other Completables
+	c := MergeCompletables([]Completable{
+		FromAction(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}),
+		FromAction(func(_ context.Context) error {
+			return errFoo
+		}),
+	})
+	assert.Equal(t, errFoo, c.Get())
+}
+
+func Test_MergeDelayError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := MergeDelayError([]Observable{testObservable(ctx, 1, errFoo, 2), testObservable(ctx, 3, errBar, 4)})
+	Assert(context.Background(), t, obs, HasItemsNoOrder(1, 2, 3, 4), HasAnError())
+}
+
+func Test_MergeDelayError_CompositeError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := MergeDelayError([]Observable{testObservable(ctx, 1, errFoo), testObservable(ctx, 2, errBar)}).Error()
+	assert.True(t, errors.Is(err, errFoo))
+	assert.True(t, errors.Is(err, errBar))
+	var composite CompositeError
+	assert.True(t, errors.As(err, &composite))
+	assert.Len(t, composite.Errs, 2)
+}
+
+func Test_MergeDelayError_Errors(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := MergeDelayError([]Observable{testObservable(ctx, 1, errFoo), testObservable(ctx, 2, errBar)}).Errors()
+	assert.ElementsMatch(t, []error{errFoo, errBar}, errs)
+}
+
+func Test_MergeDelayError_NoError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := MergeDelayError([]Observable{testObservable(ctx, 1, 2), testObservable(ctx, 3, 4)})
+	Assert(context.Background(), t, obs, HasItemsNoOrder(1, 2, 3, 4), HasNoError())
+}
+
 func Test_Merge_Error(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -524,3 +833,32 @@ func Test_Timer_Empty(t *testing.T) {
 	case <-obs.Observe():
 	}
 }
+
+func Test_ZipSingles(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	single := ZipSingles([]Single{JustItem(1), JustItem(2).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) * 10, nil
+	}), JustItem(3)}, func(values ...interface{}) interface{} {
+		return values
+	})
+	Assert(ctx, t, single, HasItem([]interface{}{1, 20, 3}))
+}
+
+func Test_ZipSingles_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// The error cancels the other Singles
+	single := ZipSingles([]Single{
+		FromAction(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}).ToSingle(1),
+		JustItem(errFoo),
+	}, func(values ...interface{}) interface{} {
+		return values
+	})
+	Assert(ctx, t, single, IsEmpty(), HasError(errFoo))
+}
@@ -0,0 +1,250 @@
+package rxgo
+
+import (
+	"context"
+	"math"
+	"sync"
+)
+
+// Flowable is a source of items where the subscriber signals its demand: a Flowable never emits more items than
+// requested using Subscription.Request. The errors and the completion are not subject to the demand.
+type Flowable interface {
+	Filter(apply Predicate, opts ...Option) Flowable
+	Map(apply Func, opts ...Option) Flowable
+	Subscribe(opts ...Option) (<-chan Item, Subscription)
+	ToObservable(opts ...Option) Observable
+}
+
+// Subscription is the link between a Flowable and its subscriber.
+type Subscription interface {
+	// Request adds n items to the demand. A non-positive n is ignored.
+	Request(n int64)
+	// Cancel cancels the subscription. The channel of items is then closed.
+	Cancel()
+}
+
+// FlowableEmitter is used by a Flowable producer to emit items according to the demand.
+type FlowableEmitter interface {
+	// Demand blocks until items are requested and returns the number of items requested and not emitted yet.
+	// It returns 0 once the subscription is canceled.
+	Demand() int64
+	// Next emits an item, blocking until it is requested. It returns false once the subscription is canceled.
+	Next(v interface{}) bool
+	// Error emits an error, regardless of the demand.
+	Error(err error)
+}
+
+// FlowableProducer defines a Flowable producer. The Flowable completes once the producer returns.
+type FlowableProducer func(ctx context.Context, emitter FlowableEmitter)
+
+// FlowableImpl implements Flowable.
+type FlowableImpl struct {
+	subscribe func(opts ...Option) (<-chan Item, Subscription)
+}
+
+type subscription struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	mutex     sync.Mutex
+	requested int64
+	// Notified when the demand increases
+	signal chan struct{}
+}
+
+func newSubscription(parent context.Context) *subscription {
+	ctx, cancel := context.WithCancel(parent)
+	return &subscription{
+		ctx:    ctx,
+		cancel: cancel,
+		signal: make(chan struct{}, 1),
+	}
+}
+
+func (s *subscription) Request(n int64) {
+	if n <= 0 {
+		return
+	}
+	s.mutex.Lock()
+	s.requested += n
+	if s.requested < 0 {
+		// Overflow, the demand is unbounded
+		s.requested = math.MaxInt64
+	}
+	s.mutex.Unlock()
+
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscription) Cancel() {
+	s.cancel()
+}
+
+// awaitDemand blocks until items are requested and returns the pending demand, 0 if the subscription is canceled.
+func (s *subscription) awaitDemand() int64 {
+	for {
+		if s.ctx.Err() != nil {
+			return 0
+		}
+		s.mutex.Lock()
+		n := s.requested
+		s.mutex.Unlock()
+		if n > 0 {
+			return n
+		}
+		select {
+		case <-s.ctx.Done():
+			return 0
+		case <-s.signal:
+		}
+	}
+}
+
+// tryConsume consumes one requested item. It returns false if there is no pending demand.
+func (s *subscription) tryConsume() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.requested == 0 {
+		return false
+	}
+	if s.requested != math.MaxInt64 {
+		s.requested--
+	}
+	return true
+}
+
+type flowableEmitter struct {
+	sub  *subscription
+	next chan<- Item
+}
+
+func (e *flowableEmitter) Demand() int64 {
+	return e.sub.awaitDemand()
+}
+
+func (e *flowableEmitter) Next(v interface{}) bool {
+	if e.sub.awaitDemand() == 0 {
+		return false
+	}
+	e.sub.tryConsume()
+	return Of(v).SendContext(e.sub.ctx, e.next)
+}
+
+func (e *flowableEmitter) Error(err error) {
+	Error(err).SendContext(e.sub.ctx, e.next)
+}
+
+// stageSubscription forwards the demand of a Flowable operator to its upstream subscription.
+type stageSubscription struct {
+	upstream Subscription
+	cancel   context.CancelFunc
+}
+
+func (s *stageSubscription) Request(n int64) {
+	s.upstream.Request(n)
+}
+
+func (s *stageSubscription) Cancel() {
+	s.cancel()
+	s.upstream.Cancel()
+}
+
+// operator creates a Flowable calling onItem for each item of the Flowable. The demand is forwarded upstream
+// as is; onItem has to request more items if it does not emit an item. onItem returns false to cancel the
+// subscription.
+func (f *FlowableImpl) operator(onItem func(ctx context.Context, item Item, next chan<- Item, upstream Subscription) bool, opts ...Option) Flowable {
+	return &FlowableImpl{
+		subscribe: func(propagatedOptions ...Option) (<-chan Item, Subscription) {
+			mergedOptions := append(opts, propagatedOptions...)
+			option := parseOptions(mergedOptions...)
+
+			next := option.buildChannel()
+			ctx, cancel := context.WithCancel(option.buildContext(emptyContext))
+			upstream, upstreamSub := f.Subscribe(mergedOptions...)
+			sub := &stageSubscription{upstream: upstreamSub, cancel: cancel}
+
+			go func() {
+				defer close(next)
+				defer sub.Cancel()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case item, ok := <-upstream:
+						if !ok {
+							return
+						}
+						if !onItem(ctx, item, next, upstreamSub) {
+							return
+						}
+					}
+				}
+			}()
+			return next, sub
+		},
+	}
+}
+
+// Filter emits only those items from a Flowable that pass a predicate test.
+// An item not passing the test is replaced by requesting one more item upstream.
+func (f *FlowableImpl) Filter(apply Predicate, opts ...Option) Flowable {
+	return f.operator(func(ctx context.Context, item Item, next chan<- Item, upstream Subscription) bool {
+		if item.Error() || apply(item.V) {
+			return item.SendContext(ctx, next)
+		}
+		upstream.Request(1)
+		return true
+	}, opts...)
+}
+
+// Map transforms the items emitted by a Flowable by applying a function to each item.
+func (f *FlowableImpl) Map(apply Func, opts ...Option) Flowable {
+	name := parseOptions(opts...).getName()
+	return f.operator(func(ctx context.Context, item Item, next chan<- Item, _ Subscription) bool {
+		if item.Error() {
+			return item.SendContext(ctx, next)
+		}
+		v, err := apply(ctx, item.V)
+		if err != nil {
+			Error(wrapOperatorError(name, "Map", item.V, err)).SendContext(ctx, next)
+			return false
+		}
+		return Of(v).SendContext(ctx, next)
+	}, opts...)
+}
+
+// Subscribe subscribes to the Flowable. No item is emitted until it is requested using the Subscription.
+func (f *FlowableImpl) Subscribe(opts ...Option) (<-chan Item, Subscription) {
+	return f.subscribe(opts...)
+}
+
+// ToObservable converts the Flowable into an Observable, requesting one item each time an item is emitted.
+func (f *FlowableImpl) ToObservable(opts ...Option) Observable {
+	return &ObservableImpl{
+		iterable: newOperatorIterable(nil, func(ctx context.Context, next chan Item, opts ...Option) {
+			defer close(next)
+			upstream, sub := f.Subscribe(append(append([]Option{}, opts...), WithContext(ctx))...)
+			defer sub.Cancel()
+
+			sub.Request(1)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-upstream:
+					if !ok {
+						return
+					}
+					if !item.SendContext(ctx, next) {
+						return
+					}
+					if !item.Error() {
+						sub.Request(1)
+					}
+				}
+			}
+		}, opts...),
+	}
+}
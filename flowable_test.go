@@ -0,0 +1,239 @@
+package rxgo
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func infiniteFlowable() Flowable {
+	return CreateFlowable(func(_ context.Context, emitter FlowableEmitter) {
+		for i := 0; ; i++ {
+			if !emitter.Next(i) {
+				return
+			}
+		}
+	})
+}
+
+func Test_Flowable_Request(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	next, sub := infiniteFlowable().Subscribe()
+	sub.Request(2)
+	assert.Equal(t, 0, (<-next).V)
+	assert.Equal(t, 1, (<-next).V)
+
+	// No item is emitted without demand
+	select {
+	case item := <-next:
+		assert.FailNow(t, "unexpected item", item)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	sub.Request(1)
+	assert.Equal(t, 2, (<-next).V)
+	sub.Cancel()
+	for range next {
+	}
+}
+
+func Test_Flowable_Demand(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var demands []int64
+	flowable := CreateFlowable(func(_ context.Context, emitter FlowableEmitter) {
+		// Simulate a paginated source fetching as many items as requested
+		i := 0
+		for len(demands) < 2 {
+			n := emitter.Demand()
+			if n == 0 {
+				return
+			}
+			demands = append(demands, n)
+			for j := int64(0); j < n; j++ {
+				emitter.Next(i)
+				i++
+			}
+		}
+	})
+	next, sub := flowable.Subscribe()
+	sub.Request(3)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, i, (<-next).V)
+	}
+	sub.Request(2)
+	for i := 3; i < 5; i++ {
+		assert.Equal(t, i, (<-next).V)
+	}
+	_, ok := <-next
+	assert.False(t, ok)
+	assert.Equal(t, []int64{3, 2}, demands)
+}
+
+func Test_Flowable_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	next, _ := CreateFlowable(func(_ context.Context, emitter FlowableEmitter) {
+		emitter.Error(errFoo)
+	}).Subscribe()
+	// The error is emitted without demand
+	assert.Equal(t, errFoo, (<-next).E)
+	_, ok := <-next
+	assert.False(t, ok)
+}
+
+func Test_Flowable_Cancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	canceled := make(chan struct{})
+	next, sub := CreateFlowable(func(ctx context.Context, emitter FlowableEmitter) {
+		<-ctx.Done()
+		close(canceled)
+	}).Subscribe()
+	sub.Cancel()
+	<-canceled
+	_, ok := <-next
+	assert.False(t, ok)
+}
+
+func Test_Flowable_Filter(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	next, sub := infiniteFlowable().Filter(func(i interface{}) bool {
+		return i.(int)%2 == 0
+	}).Subscribe()
+	// The filtered items are replaced by requesting more items upstream
+	sub.Request(3)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, i*2, (<-next).V)
+	}
+	sub.Cancel()
+	for range next {
+	}
+}
+
+func Test_Flowable_Map(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	next, sub := infiniteFlowable().Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) * 10, nil
+	}).Subscribe()
+	sub.Request(2)
+	assert.Equal(t, 0, (<-next).V)
+	assert.Equal(t, 10, (<-next).V)
+	sub.Cancel()
+	for range next {
+	}
+}
+
+func Test_Flowable_Map_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := infiniteFlowable().Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		if i == 2 {
+			return nil, errFoo
+		}
+		return i, nil
+	}).ToObservable()
+	Assert(ctx, t, obs, HasItems(0, 1), HasError(errFoo))
+}
+
+func Test_Flowable_ToObservable(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := Range(0, 5).ToFlowable(Block).ToObservable()
+	Assert(ctx, t, obs, HasItems(0, 1, 2, 3, 4), HasNoError())
+}
+
+func Test_Observable_ToFlowable_Block(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	next, sub := Range(0, 3).ToFlowable(Block).Subscribe()
+	sub.Request(1)
+	assert.Equal(t, 0, (<-next).V)
+	sub.Request(2)
+	assert.Equal(t, 1, (<-next).V)
+	assert.Equal(t, 2, (<-next).V)
+	_, ok := <-next
+	assert.False(t, ok)
+}
+
+func Test_Observable_ToFlowable_Drop(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ch := make(chan Item)
+	next, _ := FromChannel(ch).ToFlowable(Drop).Subscribe()
+	// Without demand, the items are dropped
+	ch <- Of(1)
+	ch <- Of(2)
+	close(ch)
+	_, ok := <-next
+	assert.False(t, ok)
+}
+
+// subscribeWithoutDemand converts a source to a Flowable and sends items without demand, then requests every
+// item and returns the items received.
+func subscribeWithoutDemand(strategy BackpressureStrategy, dropped *int32, n int, opts ...Option) []Item {
+	ch := make(chan Item)
+	next, sub := FromChannel(ch).ToFlowable(strategy, append(opts, WithOnDrop(func(Item) {
+		atomic.AddInt32(dropped, 1)
+	}))...).Subscribe()
+	for i := 1; i <= n; i++ {
+		ch <- Of(i)
+	}
+	close(ch)
+	sub.Request(int64(n))
+	items := make([]Item, 0)
+	for item := range next {
+		items = append(items, item)
+	}
+	return items
+}
+
+func Test_Observable_ToFlowable_Drop_OnDrop(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var dropped int32
+	items := subscribeWithoutDemand(Drop, &dropped, 5)
+	// Only the items still in flight when the demand arrives may be received
+	assert.LessOrEqual(t, len(items), 2)
+	assert.Equal(t, 5, len(items)+int(atomic.LoadInt32(&dropped)))
+}
+
+func Test_Observable_ToFlowable_Latest(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var dropped int32
+	items := subscribeWithoutDemand(Latest, &dropped, 5)
+	// At most one item awaiting the demand plus the latest item
+	assert.LessOrEqual(t, len(items), 2)
+	assert.Equal(t, 5, items[len(items)-1].V)
+	assert.Equal(t, 5, len(items)+int(atomic.LoadInt32(&dropped)))
+}
+
+func Test_Observable_ToFlowable_DropOldest(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var dropped int32
+	items := subscribeWithoutDemand(DropOldest, &dropped, 10, WithBufferedChannel(3))
+	// At most one item awaiting the demand plus the buffered items
+	assert.LessOrEqual(t, len(items), 4)
+	assert.Equal(t, []interface{}{8, 9, 10}, []interface{}{
+		items[len(items)-3].V, items[len(items)-2].V, items[len(items)-1].V,
+	})
+	assert.Equal(t, 10, len(items)+int(atomic.LoadInt32(&dropped)))
+}
+
+func Test_Observable_ToFlowable_BufferThenError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var dropped int32
+	items := subscribeWithoutDemand(BufferThenError, &dropped, 5, WithBufferedChannel(1))
+	assert.Equal(t, BufferOverflowError{error: "capacity of 1 items exceeded"}, items[len(items)-1].E)
+	for _, item := range items[:len(items)-1] {
+		assert.False(t, item.Error())
+	}
+}
+
+func Test_Observable_ToFlowable_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	next, _ := Just(errFoo)().ToFlowable(Block).Subscribe()
+	assert.Equal(t, errFoo, (<-next).E)
+	_, ok := <-next
+	assert.False(t, ok)
+}
@@ -0,0 +1,165 @@
+package rxgo
+
+import "context"
+
+// fusedStage is an operator of a fused chain. The operator must be stateless, sequential and emit at most one
+// item per item received.
+type fusedStage struct {
+	operatorFactory func() operator
+	opts            []Option
+}
+
+// fusedIterable runs a chain of operators in a single goroutine: an item goes through every operator before the
+// next item is consumed, without crossing a channel between the operators.
+type fusedIterable struct {
+	parent context.Context
+	source Iterable
+	stages []fusedStage
+}
+
+// fusedObservable creates an Observable from a stateless operator emitting at most one item per item received.
+// If the options allow it, the operator is fused with the previous operators instead of running in its own
+// goroutine.
+func fusedObservable(parent context.Context, iterable Iterable, operatorFactory func() operator, opts ...Option) Observable {
+	option := parseOptions(opts...)
+	if option.isBatchable() {
+		return &ObservableImpl{parent: parent, iterable: newBatchIterable(parent, iterable, operatorFactory, opts...)}
+	}
+	if !option.isFusable() {
+		return observable(parent, iterable, operatorFactory, false, true, opts...)
+	}
+
+	stage := fusedStage{operatorFactory: operatorFactory, opts: opts}
+	if o, ok := iterable.(*ObservableImpl); ok {
+		if fused, ok := o.iterable.(*fusedIterable); ok && fused.parent == parent {
+			stages := make([]fusedStage, 0, len(fused.stages)+1)
+			stages = append(stages, fused.stages...)
+			return &ObservableImpl{parent: parent, iterable: &fusedIterable{
+				parent: parent,
+				source: fused.source,
+				stages: append(stages, stage),
+			}}
+		}
+	}
+	return &ObservableImpl{parent: parent, iterable: &fusedIterable{
+		parent: parent,
+		source: iterable,
+		stages: []fusedStage{stage},
+	}}
+}
+
+func (i *fusedIterable) Observe(opts ...Option) <-chan Item {
+	option := parseOptions(i.mergeOptions(len(i.stages)-1, opts)...)
+	next := option.buildChannel()
+	ctx := option.buildContext(i.parent)
+
+	go func() {
+		defer close(next)
+		i.run(ctx, opts, func(item Item) bool {
+			return item.SendContext(ctx, next)
+		})
+	}()
+	return next
+}
+
+// forEach runs the chain and calls f with each item emitted, until f returns false. This method is blocking.
+func (i *fusedIterable) forEach(f func(Item) bool, opts ...Option) {
+	ctx := parseOptions(i.mergeOptions(len(i.stages)-1, opts)...).buildContext(i.parent)
+	i.run(ctx, opts, f)
+}
+
+// mergeOptions returns the options of a stage, as if each stage was observed by the next one.
+func (i *fusedIterable) mergeOptions(stage int, propagatedOptions []Option) []Option {
+	merged := propagatedOptions
+	for j := len(i.stages) - 1; j >= stage; j-- {
+		merged = append(append([]Option{}, i.stages[j].opts...), merged...)
+	}
+	return merged
+}
+
+func (i *fusedIterable) run(ctx context.Context, propagatedOptions []Option, emit func(Item) bool) {
+	n := len(i.stages)
+	ops := make([]operator, n)
+	syncOps := make([]syncOperator, n)
+	operatorOpts := make([]operatorOptions, n)
+	// Each operator emits to a single slot buffer, drained before the next item is processed
+	dsts := make([]chan Item, n)
+	stopped := false
+	var observe <-chan Item
+
+	for j, stage := range i.stages {
+		option := parseOptions(i.mergeOptions(j, propagatedOptions)...)
+		stageOption := parseOptions(stage.opts...)
+		ops[j] = stage.operatorFactory()
+		syncOps[j], _ = ops[j].(syncOperator)
+		dsts[j] = make(chan Item, 1)
+		operatorOpts[j] = operatorOptions{
+			stop: func() {
+				if option.getErrorStrategy() == StopOnError {
+					stopped = true
+				}
+			},
+			resetIterable: func(newIterable Iterable) {
+				observe = newIterable.Observe(i.mergeOptions(0, propagatedOptions)...)
+			},
+			name:       stageOption.getName(),
+			deadLetter: stageOption.getDeadLetter(),
+		}
+	}
+
+	// process passes an item through the operators from a given stage. It returns false if the items cannot be
+	// emitted anymore.
+	var process func(stage int, item Item) bool
+	process = func(stage int, item Item) bool {
+		if stage == n {
+			return emit(item)
+		}
+		switch {
+		case item.Error():
+			ops[stage].err(ctx, item, dsts[stage], operatorOpts[stage])
+		case syncOps[stage] != nil:
+			if res, ok := syncOps[stage].process(ctx, item, operatorOpts[stage]); ok {
+				return process(stage+1, res)
+			}
+			return true
+		default:
+			ops[stage].next(ctx, item, dsts[stage], operatorOpts[stage])
+		}
+		return drain(stage, process, dsts)
+	}
+
+	observe = i.source.Observe(i.mergeOptions(0, propagatedOptions)...)
+loop:
+	for !stopped {
+		select {
+		case <-ctx.Done():
+			break loop
+		case item, ok := <-observe:
+			if !ok {
+				break loop
+			}
+			if !process(0, item) {
+				return
+			}
+		}
+	}
+	for j, op := range ops {
+		op.end(ctx, dsts[j])
+		if !drain(j, process, dsts) {
+			return
+		}
+	}
+}
+
+func drain(stage int, process func(stage int, item Item) bool, dsts []chan Item) bool {
+	for {
+		select {
+		case item := <-dsts[stage]:
+			if !process(stage+1, item) {
+				return false
+			}
+		default:
+			return true
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package rxgo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func Test_Fusion(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obs := Just(1, 2, 3, 4, 5)().
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) * 10, nil
+		}).
+		Filter(func(i interface{}) bool {
+			return i.(int) > 20
+		}).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) + 1, nil
+		})
+	fused, ok := obs.(*ObservableImpl).iterable.(*fusedIterable)
+	assert.True(t, ok)
+	assert.Len(t, fused.stages, 3)
+
+	Assert(ctx, t, obs, HasItems(31, 41, 51), HasNoError())
+	// A fused Observable can be observed several times
+	Assert(ctx, t, obs, HasItems(31, 41, 51), HasNoError())
+}
+
+func Test_Fusion_IncompatibleOptions(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obs := testObservable(ctx, 1, 2, 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) * 10, nil
+		}, WithBufferedChannel(1))
+	_, ok := obs.(*ObservableImpl).iterable.(*fusedIterable)
+	assert.False(t, ok)
+
+	obs = obs.Filter(func(i interface{}) bool {
+		return i.(int) > 10
+	})
+	fused, ok := obs.(*ObservableImpl).iterable.(*fusedIterable)
+	assert.True(t, ok)
+	assert.Len(t, fused.stages, 1)
+	Assert(ctx, t, obs, HasItems(20, 30), HasNoError())
+}
+
+func Test_Fusion_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obs := testObservable(ctx, 1, 2, 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			if i == 2 {
+				return nil, errFoo
+			}
+			return i, nil
+		}, WithName("parse")).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) * 10, nil
+		})
+	Assert(ctx, t, obs, HasItems(10), HasError(OperatorError{
+		Name:     "parse",
+		Operator: "Map",
+		Value:    2,
+		Err:      errFoo,
+	}))
+}
+
+func Test_Fusion_NameAndDeadLetter(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deadLetter := make(chan Item, 1)
+	failOn := func(v int) Func {
+		return func(_ context.Context, i interface{}) (interface{}, error) {
+			if i == v {
+				return nil, errFoo
+			}
+			return i, nil
+		}
+	}
+	obs := testObservable(ctx, 1, 2, 3, 4, 5).
+		Map(failOn(2), WithDeadLetter(deadLetter)).
+		Map(failOn(4), WithName("second"))
+	fused, ok := obs.(*ObservableImpl).iterable.(*fusedIterable)
+	assert.True(t, ok)
+	assert.Len(t, fused.stages, 2)
+
+	// Each stage keeps its own options
+	Assert(ctx, t, obs, HasItems(1, 3), HasError(OperatorError{
+		Name:     "second",
+		Operator: "Map",
+		Value:    4,
+		Err:      errFoo,
+	}))
+	close(deadLetter)
+	Assert(ctx, t, FromChannel(deadLetter), HasItems(DeadLetter{V: 2, E: errFoo}))
+}
+
+func Test_Fusion_ContinueOnError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obs := testObservable(ctx, 1, 2, 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			if i == 2 {
+				return nil, errFoo
+			}
+			return i, nil
+		}).
+		Filter(func(i interface{}) bool {
+			return i.(int) > 1
+		}, WithErrorStrategy(ContinueOnError))
+	Assert(ctx, t, obs, HasItems(3), HasError(errFoo))
+}
+
+func Test_Fusion_DoOnNext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := make([]interface{}, 0)
+	<-testObservable(ctx, 1, 2, errors.New("bar"), 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) * 10, nil
+		}).
+		DoOnNext(func(i interface{}) {
+			s = append(s, i)
+		})
+	assert.Equal(t, []interface{}{10, 20}, s)
+}
+
+func Test_Fusion_DoOnNext_OperatorError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deadLetter := make(chan Item, 1)
+	s := make([]interface{}, 0)
+	<-testObservable(ctx, 1, 2, 3, 4).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			if i == 2 {
+				return nil, errFoo
+			}
+			return i, nil
+		}, WithDeadLetter(deadLetter)).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			if i == 4 {
+				return nil, errFoo
+			}
+			return i, nil
+		}, WithName("second")).
+		DoOnNext(func(i interface{}) {
+			s = append(s, i)
+		})
+	// The routed error does not stop the callbacks, the wrapped one does
+	assert.Equal(t, []interface{}{1, 3}, s)
+	close(deadLetter)
+	Assert(ctx, t, FromChannel(deadLetter), HasItems(DeadLetter{V: 2, E: errFoo}))
+}
+
+func Test_Fusion_Cancel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	next := make(chan Item)
+
+	obs := FromChannel(next).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}).
+		Filter(func(interface{}) bool {
+			return true
+		})
+	observe := obs.Observe(WithContext(ctx))
+	next <- Of(1)
+	assert.Equal(t, Of(1), <-observe)
+	cancel()
+	for range observe {
+	}
+	close(next)
+}
@@ -19,6 +19,13 @@ type (
 		V         interface{}
 	}
 
+	// DeadLetter pairs an input value with the error it produced.
+	// It is the item type sent to a sink configured using WithDeadLetter.
+	DeadLetter struct {
+		V interface{}
+		E error
+	}
+
 	// CloseChannelStrategy indicates a strategy on whether to close a channel.
 	CloseChannelStrategy uint32
 )
@@ -0,0 +1,51 @@
+package rxgo
+
+import "sync"
+
+// cacheIterable subscribes once to an Iterable and replays its items to every observer.
+// The subscription is triggered by the first observation and is not bound to the observer context.
+type cacheIterable struct {
+	iterable Iterable
+	once     sync.Once
+	done     chan struct{}
+	items    []Item
+}
+
+func newCacheIterable(iterable Iterable) Iterable {
+	return &cacheIterable{
+		iterable: iterable,
+		done:     make(chan struct{}),
+	}
+}
+
+func (i *cacheIterable) Observe(opts ...Option) <-chan Item {
+	i.once.Do(func() {
+		observe := i.iterable.Observe()
+		go func() {
+			defer close(i.done)
+			for item := range observe {
+				i.items = append(i.items, item)
+			}
+		}()
+	})
+
+	option := parseOptions(opts...)
+	next := option.buildChannel()
+	ctx := option.buildContext(emptyContext)
+
+	go func() {
+		defer close(next)
+		select {
+		case <-ctx.Done():
+			return
+		case <-i.done:
+		}
+		for _, item := range i.items {
+			if !item.SendContext(ctx, next) {
+				return
+			}
+		}
+	}()
+
+	return next
+}
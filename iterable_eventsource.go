@@ -7,14 +7,20 @@ import (
 
 type eventSourceIterable struct {
 	sync.RWMutex
+	ctx       context.Context
 	observers []chan Item
 	disposed  bool
+	strategy  BackpressureStrategy
+	onDrop    func(Item)
 	opts      []Option
 }
 
-func newEventSourceIterable(ctx context.Context, next <-chan Item, strategy BackpressureStrategy, opts ...Option) Iterable {
+func newEventSourceIterable(ctx context.Context, next <-chan Item, strategy BackpressureStrategy, onDrop func(Item), opts ...Option) Iterable {
 	it := &eventSourceIterable{
+		ctx:       ctx,
 		observers: make([]chan Item, 0),
+		strategy:  strategy,
+		onDrop:    onDrop,
 		opts:      opts,
 	}
 
@@ -29,8 +35,7 @@ func newEventSourceIterable(ctx context.Context, next <-chan Item, strategy Back
 
 			switch strategy {
 			default:
-				fallthrough
-			case Block:
+				// Either Block or a strategy applied by a relay per observer
 				for _, observer := range it.observers {
 					if !item.SendContext(ctx, observer) {
 						return true
@@ -40,6 +45,9 @@ func newEventSourceIterable(ctx context.Context, next <-chan Item, strategy Back
 				for _, observer := range it.observers {
 					select {
 					default:
+						if onDrop != nil {
+							onDrop(item)
+						}
 					case <-ctx.Done():
 						return true
 					case observer <- item:
@@ -80,12 +88,16 @@ func (i *eventSourceIterable) closeAllObservers() {
 func (i *eventSourceIterable) Observe(opts ...Option) <-chan Item {
 	option := parseOptions(append(i.opts, opts...)...)
 	next := option.buildChannel()
+	observer := next
+	if i.strategy != Block && i.strategy != Drop {
+		observer, next = newBackpressureRelay(i.ctx, next, i.strategy, i.onDrop)
+	}
 
 	i.Lock()
 	if i.disposed {
-		close(next)
+		close(observer)
 	} else {
-		i.observers = append(i.observers, next)
+		i.observers = append(i.observers, observer)
 	}
 	i.Unlock()
 	return next
@@ -1,5 +1,7 @@
 package rxgo
 
+import "context"
+
 type factoryIterable struct {
 	factory func(opts ...Option) <-chan Item
 }
@@ -11,3 +13,24 @@ func newFactoryIterable(factory func(opts ...Option) <-chan Item) Iterable {
 func (i *factoryIterable) Observe(opts ...Option) <-chan Item {
 	return i.factory(opts...)
 }
+
+// newOperatorIterable creates an Iterable running f in a goroutine at each observation, or once if the
+// observation is eager. f must close next once done.
+func newOperatorIterable(parent context.Context, f func(ctx context.Context, next chan Item, opts ...Option), opts ...Option) Iterable {
+	option := parseOptions(opts...)
+
+	if option.isEagerObservation() {
+		next := option.buildChannel()
+		go f(option.buildContext(parent), next, opts...)
+		return newChannelIterable(next)
+	}
+
+	return newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+		mergedOptions := append(opts, propagatedOptions...)
+		option := parseOptions(mergedOptions...)
+
+		next := option.buildChannel()
+		go f(option.buildContext(parent), next, mergedOptions...)
+		return next
+	})
+}
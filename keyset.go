@@ -0,0 +1,159 @@
+package rxgo
+
+import (
+	"container/list"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"time"
+)
+
+// keySet is the dedup state of the Distinct operator.
+type keySet interface {
+	// add adds a key and returns whether it was already present.
+	add(key interface{}) bool
+}
+
+// mapKeySet keeps every key ever seen.
+type mapKeySet struct {
+	keys map[interface{}]struct{}
+}
+
+func newMapKeySet() keySet {
+	return &mapKeySet{keys: make(map[interface{}]struct{})}
+}
+
+func (s *mapKeySet) add(key interface{}) bool {
+	if _, contains := s.keys[key]; contains {
+		return true
+	}
+	s.keys[key] = struct{}{}
+	return false
+}
+
+// ttlKeySet keeps a key for a given time to live after it was first seen.
+type ttlKeySet struct {
+	ttl  time.Duration
+	now  func() time.Time
+	keys map[interface{}]*list.Element
+	// Keys ordered by insertion time
+	order *list.List
+}
+
+type ttlEntry struct {
+	key      interface{}
+	deadline time.Time
+}
+
+func newTTLKeySet(ttl time.Duration) (keySet, error) {
+	if ttl <= 0 {
+		return nil, IllegalInputError{error: "distinct TTL must be positive"}
+	}
+	return &ttlKeySet{
+		ttl:   ttl,
+		now:   time.Now,
+		keys:  make(map[interface{}]*list.Element),
+		order: list.New(),
+	}, nil
+}
+
+func (s *ttlKeySet) add(key interface{}) bool {
+	now := s.now()
+	for {
+		front := s.order.Front()
+		if front == nil || front.Value.(ttlEntry).deadline.After(now) {
+			break
+		}
+		s.order.Remove(front)
+		delete(s.keys, front.Value.(ttlEntry).key)
+	}
+
+	if _, contains := s.keys[key]; contains {
+		return true
+	}
+	s.keys[key] = s.order.PushBack(ttlEntry{key: key, deadline: now.Add(s.ttl)})
+	return false
+}
+
+// lruKeySet keeps a maximum number of keys, evicting the least recently seen one.
+type lruKeySet struct {
+	capacity int
+	keys     map[interface{}]*list.Element
+	// Keys ordered from the most to the least recently seen
+	order *list.List
+}
+
+func newLRUKeySet(capacity int) (keySet, error) {
+	if capacity <= 0 {
+		return nil, IllegalInputError{error: "distinct LRU capacity must be positive"}
+	}
+	return &lruKeySet{
+		capacity: capacity,
+		keys:     make(map[interface{}]*list.Element),
+		order:    list.New(),
+	}, nil
+}
+
+func (s *lruKeySet) add(key interface{}) bool {
+	if elem, contains := s.keys[key]; contains {
+		s.order.MoveToFront(elem)
+		return true
+	}
+	if s.order.Len() >= s.capacity {
+		back := s.order.Back()
+		s.order.Remove(back)
+		delete(s.keys, back.Value)
+	}
+	s.keys[key] = s.order.PushFront(key)
+	return false
+}
+
+// bloomKeySet is a probabilistic key set based on a Bloom filter.
+// It never misses a duplicate but may consider a new key as a duplicate, according to a false positive rate.
+type bloomKeySet struct {
+	bits   []uint64
+	m      uint64
+	hashes uint64
+}
+
+func newBloomKeySet(expectedItems int, falsePositiveRate float64) (keySet, error) {
+	if expectedItems <= 0 {
+		return nil, IllegalInputError{error: "distinct Bloom filter expected items must be positive"}
+	}
+	// NaN is rejected too
+	if !(falsePositiveRate > 0 && falsePositiveRate < 1) {
+		return nil, IllegalInputError{error: "distinct Bloom filter false positive rate must be in (0, 1)"}
+	}
+	n := float64(expectedItems)
+	m := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	if m < 64 {
+		m = 64
+	}
+	k := math.Round(m / n * math.Ln2)
+	if k < 1 {
+		k = 1
+	}
+	return &bloomKeySet{
+		bits:   make([]uint64, (uint64(m)+63)/64),
+		m:      uint64(m),
+		hashes: uint64(k),
+	}, nil
+}
+
+func (s *bloomKeySet) add(key interface{}) bool {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%T:%v", key, key)
+	sum := h.Sum64()
+	// Double hashing to compute the k hashes
+	h1, h2 := sum&math.MaxUint32, sum>>32
+	contains := true
+	for i := uint64(0); i < s.hashes; i++ {
+		bit := (h1 + i*h2) % s.m
+		idx, mask := bit/64, uint64(1)<<(bit%64)
+		if s.bits[idx]&mask == 0 {
+			contains = false
+			s.bits[idx] |= mask
+		}
+	}
+	return contains
+}
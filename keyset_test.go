@@ -0,0 +1,67 @@
+package rxgo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_KeySet_Map(t *testing.T) {
+	s := newMapKeySet()
+	assert.False(t, s.add(1))
+	assert.True(t, s.add(1))
+	assert.False(t, s.add(2))
+}
+
+func Test_KeySet_TTL(t *testing.T) {
+	now := time.Now()
+	set, err := newTTLKeySet(time.Second)
+	assert.NoError(t, err)
+	s := set.(*ttlKeySet)
+	s.now = func() time.Time {
+		return now
+	}
+	assert.False(t, s.add(1))
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, s.add(1))
+	assert.False(t, s.add(2))
+	now = now.Add(500 * time.Millisecond)
+	assert.False(t, s.add(1))
+	assert.True(t, s.add(2))
+	assert.Equal(t, 2, len(s.keys))
+	now = now.Add(2 * time.Second)
+	assert.False(t, s.add(3))
+	assert.Equal(t, 1, len(s.keys))
+}
+
+func Test_KeySet_LRU(t *testing.T) {
+	set, err := newLRUKeySet(2)
+	assert.NoError(t, err)
+	s := set.(*lruKeySet)
+	assert.False(t, s.add(1))
+	assert.False(t, s.add(2))
+	assert.True(t, s.add(1))
+	// 2 is the least recently seen key
+	assert.False(t, s.add(3))
+	assert.True(t, s.add(1))
+	assert.False(t, s.add(2))
+	assert.Equal(t, 2, len(s.keys))
+}
+
+func Test_KeySet_BloomFilter(t *testing.T) {
+	const n = 10000
+	s, err := newBloomKeySet(n, 0.01)
+	assert.NoError(t, err)
+	falsePositives := 0
+	for i := 0; i < n; i++ {
+		if s.add(fmt.Sprintf("key-%d", i)) {
+			falsePositives++
+		}
+	}
+	for i := 0; i < n; i++ {
+		assert.True(t, s.add(fmt.Sprintf("key-%d", i)))
+	}
+	assert.Less(t, falsePositives, n/50)
+}
@@ -0,0 +1,43 @@
+package rxgo
+
+import "fmt"
+
+// toFloat64 coerces any Go numeric type to a float64.
+// It returns false if the value is not numeric.
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case uintptr:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// numericError is the error raised by the statistical operators on a non-numeric item.
+func numericError(v interface{}) error {
+	return IllegalInputError{error: fmt.Sprintf("expected a numeric type, got: %T", v)}
+}
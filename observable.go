@@ -3,6 +3,7 @@ package rxgo
 
 import (
 	"context"
+	"hash/fnv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -15,6 +16,7 @@ import (
 type Observable interface {
 	Iterable
 	All(predicate Predicate, opts ...Option) Single
+	Aggregate(zero func() interface{}, accumulate, combine Func2, opts ...Option) Single
 	AverageFloat32(opts ...Option) Single
 	AverageFloat64(opts ...Option) Single
 	AverageInt(opts ...Option) Single
@@ -23,12 +25,19 @@ type Observable interface {
 	AverageInt32(opts ...Option) Single
 	AverageInt64(opts ...Option) Single
 	BackOffRetry(backOffCfg backoff.BackOff, opts ...Option) Observable
+	BufferToggle(openings Observable, closingSelector func(interface{}) Observable, opts ...Option) Observable
+	BufferUntil(apply Predicate, opts ...Option) Observable
+	BufferWhen(boundary Observable, opts ...Option) Observable
 	BufferWithCount(count int, opts ...Option) Observable
+	BufferWithCountSkip(count, skip int, opts ...Option) Observable
 	BufferWithTime(timespan Duration, opts ...Option) Observable
 	BufferWithTimeOrCount(timespan Duration, count int, opts ...Option) Observable
+	BufferWithTimeShift(timespan, timeshift Duration, opts ...Option) Observable
 	Connect(ctx context.Context) (context.Context, Disposable)
 	Contains(equal Predicate, opts ...Option) Single
 	Count(opts ...Option) Single
+	CountByKey(keySelector Func, opts ...Option) Single
+	CountByKeyWithTime(timespan Duration, keySelector Func, opts ...Option) Observable
 	Debounce(timespan Duration, opts ...Option) Observable
 	DefaultIfEmpty(defaultValue interface{}, opts ...Option) Observable
 	Distinct(apply Func, opts ...Option) Observable
@@ -39,6 +48,7 @@ type Observable interface {
 	ElementAt(index uint, opts ...Option) Single
 	Error(opts ...Option) error
 	Errors(opts ...Option) []error
+	EventTimeWindow(timeExtractor func(interface{}) time.Time, size Duration, slide Duration, opts ...Option) Observable
 	Filter(apply Predicate, opts ...Option) Observable
 	Find(find Predicate, opts ...Option) OptionalSingle
 	First(opts ...Option) OptionalSingle
@@ -47,17 +57,24 @@ type Observable interface {
 	ForEach(nextFunc NextFunc, errFunc ErrFunc, completedFunc CompletedFunc, opts ...Option) Disposed
 	GroupBy(length int, distribution func(Item) int, opts ...Option) Observable
 	GroupByDynamic(distribution func(Item) string, opts ...Option) Observable
+	Histogram(upperBounds []float64, opts ...Option) Single
+	HistogramWithTime(timespan Duration, upperBounds []float64, opts ...Option) Observable
 	IgnoreElements(opts ...Option) Observable
 	Join(joiner Func2, right Observable, timeExtractor func(interface{}) time.Time, window Duration, opts ...Option) Observable
+	JoinByKey(joiner Func2, right Observable, leftKey, rightKey func(interface{}) interface{}, joinType JoinType, window Duration, opts ...Option) Observable
 	Last(opts ...Option) OptionalSingle
 	LastOrDefault(defaultValue interface{}, opts ...Option) Single
 	Map(apply Func, opts ...Option) Observable
 	Marshal(marshaller Marshaller, opts ...Option) Observable
 	Max(comparator Comparator, opts ...Option) OptionalSingle
 	Min(comparator Comparator, opts ...Option) OptionalSingle
+	MinMax(opts ...Option) OptionalSingle
+	MinMaxWithTime(timespan Duration, opts ...Option) Observable
 	OnErrorResumeNext(resumeSequence ErrorToObservable, opts ...Option) Observable
 	OnErrorReturn(resumeFunc ErrorFunc, opts ...Option) Observable
 	OnErrorReturnItem(resume interface{}, opts ...Option) Observable
+	Percentiles(qs []float64, opts ...Option) OptionalSingle
+	PercentilesWithTime(timespan Duration, qs []float64, opts ...Option) Observable
 	Reduce(apply Func2, opts ...Option) OptionalSingle
 	Repeat(count int64, frequency Duration, opts ...Option) Observable
 	Retry(count int, shouldRetry func(error) bool, opts ...Option) Observable
@@ -67,10 +84,15 @@ type Observable interface {
 	SequenceEqual(iterable Iterable, opts ...Option) Single
 	Send(output chan<- Item, opts ...Option)
 	Serialize(from int, identifier func(interface{}) int, opts ...Option) Observable
+	SessionWindow(keyFunc func(Item) string, gap Duration, maxDuration Duration, opts ...Option) Observable
 	Skip(nth uint, opts ...Option) Observable
 	SkipLast(nth uint, opts ...Option) Observable
 	SkipWhile(apply Predicate, opts ...Option) Observable
+	Sort(comparator Comparator, opts ...Option) Observable
+	SortExternal(comparator Comparator, memLimit int, tmpDir string, codec Codec, opts ...Option) Observable
 	StartWith(iterable Iterable, opts ...Option) Observable
+	StdDev(opts ...Option) Single
+	StdDevWithTime(timespan Duration, opts ...Option) Observable
 	SumFloat32(opts ...Option) OptionalSingle
 	SumFloat64(opts ...Option) OptionalSingle
 	SumInt64(opts ...Option) OptionalSingle
@@ -80,13 +102,21 @@ type Observable interface {
 	TakeWhile(apply Predicate, opts ...Option) Observable
 	TimeInterval(opts ...Option) Observable
 	Timestamp(opts ...Option) Observable
+	ToFlowable(strategy BackpressureStrategy, opts ...Option) Flowable
 	ToMap(keySelector Func, opts ...Option) Single
 	ToMapWithValueSelector(keySelector, valueSelector Func, opts ...Option) Single
 	ToSlice(initialCapacity int, opts ...Option) ([]interface{}, error)
+	TopK(k int, keySelector Func, opts ...Option) Single
+	TopKWithTime(timespan Duration, k int, keySelector Func, opts ...Option) Observable
 	Unmarshal(unmarshaller Unmarshaller, factory func() interface{}, opts ...Option) Observable
+	Variance(opts ...Option) Single
+	VarianceWithTime(timespan Duration, opts ...Option) Observable
+	WindowWhen(boundary Observable, opts ...Option) Observable
 	WindowWithCount(count int, opts ...Option) Observable
+	WindowWithCountSkip(count, skip int, opts ...Option) Observable
 	WindowWithTime(timespan Duration, opts ...Option) Observable
 	WindowWithTimeOrCount(timespan Duration, count int, opts ...Option) Observable
+	WindowWithTimeShift(timespan, timeshift Duration, opts ...Option) Observable
 	ZipFromIterable(iterable Iterable, zipper Func2, opts ...Option) Observable
 }
 
@@ -101,21 +131,33 @@ func defaultErrorFuncOperator(ctx context.Context, item Item, dst chan<- Item, o
 	operatorOptions.stop()
 }
 
+// sendDeadLetter routes a failed input to the dead letter sink, if any.
+// It returns a boolean to indicate whether the input was routed. A routed error is not an error of the operator:
+// the caller does not stop, whatever the error strategy.
+func sendDeadLetter(ctx context.Context, sink chan<- Item, value interface{}, err error) bool {
+	if sink == nil {
+		return false
+	}
+	Of(DeadLetter{V: value, E: err}).SendContext(ctx, sink)
+	return true
+}
+
 func customObservableOperator(parent context.Context, f func(ctx context.Context, next chan Item, option Option, opts ...Option), opts ...Option) Observable {
 	option := parseOptions(opts...)
-	next := option.buildChannel()
 	ctx := option.buildContext(parent)
 
 	if option.isEagerObservation() {
+		next, out := buildOutputChannel(ctx, option, option)
 		go f(ctx, next, option, opts...)
-		return &ObservableImpl{iterable: newChannelIterable(next)}
+		return &ObservableImpl{iterable: newChannelIterable(out)}
 	}
 
 	return &ObservableImpl{
 		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
 			mergedOptions := append(opts, propagatedOptions...)
+			next, out := buildOutputChannel(ctx, option, option)
 			go f(ctx, next, option, mergedOptions...)
-			return next
+			return out
 		}),
 	}
 }
@@ -127,19 +169,32 @@ type operator interface {
 	gatherNext(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions)
 }
 
+// syncOperator is implemented by an operator emitting at most one item per value, to process a value without
+// crossing a channel.
+type syncOperator interface {
+	// process returns the item to emit, if any.
+	process(ctx context.Context, item Item, operatorOptions operatorOptions) (Item, bool)
+}
+
 func observable(parent context.Context, iterable Iterable, operatorFactory func() operator, forceSeq, bypassGather bool, opts ...Option) Observable {
 	option := parseOptions(opts...)
+	if err := checkKeyedPool(option); err != nil {
+		return Thrown(err)
+	}
 	parallel, _ := option.getPool()
+	// Some options (e.g. the name) are resolved from the stage options only as they must not be inherited
+	// from the downstream stages.
+	stageOption := option
 
 	if option.isEagerObservation() {
-		next := option.buildChannel()
 		ctx := option.buildContext(parent)
+		next, out := buildOutputChannel(ctx, option, stageOption)
 		if forceSeq || !parallel {
-			runSequential(ctx, next, iterable, operatorFactory, option, opts...)
+			runSequential(ctx, next, iterable, operatorFactory, option, stageOption, opts...)
 		} else {
-			runParallel(ctx, next, iterable.Observe(opts...), operatorFactory, bypassGather, option, opts...)
+			runParallel(ctx, next, iterable.Observe(opts...), operatorFactory, bypassGather, option, stageOption, opts...)
 		}
-		return &ObservableImpl{iterable: newChannelIterable(next)}
+		return &ObservableImpl{iterable: newChannelIterable(out)}
 	}
 
 	if forceSeq || !parallel {
@@ -148,10 +203,10 @@ func observable(parent context.Context, iterable Iterable, operatorFactory func(
 				mergedOptions := append(opts, propagatedOptions...)
 				option := parseOptions(mergedOptions...)
 
-				next := option.buildChannel()
 				ctx := option.buildContext(parent)
-				runSequential(ctx, next, iterable, operatorFactory, option, mergedOptions...)
-				return next
+				next, out := buildOutputChannel(ctx, option, stageOption)
+				runSequential(ctx, next, iterable, operatorFactory, option, stageOption, mergedOptions...)
+				return out
 			}),
 		}
 	}
@@ -177,10 +232,10 @@ func observable(parent context.Context, iterable Iterable, operatorFactory func(
 							return
 						}
 						Of(firstItemID.V.(int)).SendContext(ctx, fromCh)
-						runParallel(ctx, next, observe, operatorFactory, bypassGather, option, mergedOptions...)
+						runParallel(ctx, next, observe, operatorFactory, bypassGather, option, stageOption, mergedOptions...)
 					}
 				}()
-				runFirstItem(ctx, f, firstItemIDCh, observe, next, operatorFactory, option, mergedOptions...)
+				runFirstItem(ctx, f, firstItemIDCh, observe, next, operatorFactory, option, stageOption, mergedOptions...)
 				return next
 			}),
 		}
@@ -192,57 +247,67 @@ func observable(parent context.Context, iterable Iterable, operatorFactory func(
 			mergedOptions := append(opts, propagatedOptions...)
 			option := parseOptions(mergedOptions...)
 
-			next := option.buildChannel()
 			ctx := option.buildContext(parent)
-			runParallel(ctx, next, iterable.Observe(mergedOptions...), operatorFactory, bypassGather, option, mergedOptions...)
-			return next
+			next, out := buildOutputChannel(ctx, option, stageOption)
+			runParallel(ctx, next, iterable.Observe(mergedOptions...), operatorFactory, bypassGather, option, stageOption, mergedOptions...)
+			return out
 		}),
 	}
 }
 
 func single(parent context.Context, iterable Iterable, operatorFactory func() operator, forceSeq, bypassGather bool, opts ...Option) Single {
 	option := parseOptions(opts...)
+	if err := checkKeyedPool(option); err != nil {
+		return &SingleImpl{iterable: Thrown(err)}
+	}
 	parallel, _ := option.getPool()
-	next := option.buildChannel()
-	ctx := option.buildContext(parent)
+	stageOption := option
 
 	if option.isEagerObservation() {
+		ctx := option.buildContext(parent)
+		next, out := buildOutputChannel(ctx, option, stageOption)
 		if forceSeq || !parallel {
-			runSequential(ctx, next, iterable, operatorFactory, option, opts...)
+			runSequential(ctx, next, iterable, operatorFactory, option, stageOption, opts...)
 		} else {
-			runParallel(ctx, next, iterable.Observe(opts...), operatorFactory, bypassGather, option, opts...)
+			runParallel(ctx, next, iterable.Observe(opts...), operatorFactory, bypassGather, option, stageOption, opts...)
 		}
-		return &SingleImpl{iterable: newChannelIterable(next)}
+		return &SingleImpl{iterable: newChannelIterable(out)}
 	}
 
 	return &SingleImpl{
 		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
 			mergedOptions := append(opts, propagatedOptions...)
-			option = parseOptions(mergedOptions...)
+			option := parseOptions(mergedOptions...)
 
+			ctx := option.buildContext(parent)
+			next, out := buildOutputChannel(ctx, option, stageOption)
 			if forceSeq || !parallel {
-				runSequential(ctx, next, iterable, operatorFactory, option, mergedOptions...)
+				runSequential(ctx, next, iterable, operatorFactory, option, stageOption, mergedOptions...)
 			} else {
-				runParallel(ctx, next, iterable.Observe(mergedOptions...), operatorFactory, bypassGather, option, mergedOptions...)
+				runParallel(ctx, next, iterable.Observe(mergedOptions...), operatorFactory, bypassGather, option, stageOption, mergedOptions...)
 			}
-			return next
+			return out
 		}),
 	}
 }
 
 func optionalSingle(parent context.Context, iterable Iterable, operatorFactory func() operator, forceSeq, bypassGather bool, opts ...Option) OptionalSingle {
 	option := parseOptions(opts...)
+	if err := checkKeyedPool(option); err != nil {
+		return &OptionalSingleImpl{iterable: Thrown(err)}
+	}
 	ctx := option.buildContext(parent)
 	parallel, _ := option.getPool()
+	stageOption := option
 
 	if option.isEagerObservation() {
-		next := option.buildChannel()
+		next, out := buildOutputChannel(ctx, option, stageOption)
 		if forceSeq || !parallel {
-			runSequential(ctx, next, iterable, operatorFactory, option, opts...)
+			runSequential(ctx, next, iterable, operatorFactory, option, stageOption, opts...)
 		} else {
-			runParallel(ctx, next, iterable.Observe(opts...), operatorFactory, bypassGather, option, opts...)
+			runParallel(ctx, next, iterable.Observe(opts...), operatorFactory, bypassGather, option, stageOption, opts...)
 		}
-		return &OptionalSingleImpl{iterable: newChannelIterable(next)}
+		return &OptionalSingleImpl{iterable: newChannelIterable(out)}
 	}
 
 	return &OptionalSingleImpl{
@@ -251,19 +316,19 @@ func optionalSingle(parent context.Context, iterable Iterable, operatorFactory f
 			mergedOptions := append(opts, propagatedOptions...)
 			option = parseOptions(mergedOptions...)
 
-			next := option.buildChannel()
 			ctx := option.buildContext(parent)
+			next, out := buildOutputChannel(ctx, option, stageOption)
 			if forceSeq || !parallel {
-				runSequential(ctx, next, iterable, operatorFactory, option, mergedOptions...)
+				runSequential(ctx, next, iterable, operatorFactory, option, stageOption, mergedOptions...)
 			} else {
-				runParallel(ctx, next, iterable.Observe(mergedOptions...), operatorFactory, bypassGather, option, mergedOptions...)
+				runParallel(ctx, next, iterable.Observe(mergedOptions...), operatorFactory, bypassGather, option, stageOption, mergedOptions...)
 			}
-			return next
+			return out
 		}),
 	}
 }
 
-func runSequential(ctx context.Context, next chan Item, iterable Iterable, operatorFactory func() operator, option Option, opts ...Option) {
+func runSequential(ctx context.Context, next chan Item, iterable Iterable, operatorFactory func() operator, option, stageOption Option, opts ...Option) {
 	observe := iterable.Observe(opts...)
 	go func() {
 		op := operatorFactory()
@@ -277,6 +342,8 @@ func runSequential(ctx context.Context, next chan Item, iterable Iterable, opera
 			resetIterable: func(newIterable Iterable) {
 				observe = newIterable.Observe(opts...)
 			},
+			name:       stageOption.getName(),
+			deadLetter: stageOption.getDeadLetter(),
 		}
 
 	loop:
@@ -300,7 +367,7 @@ func runSequential(ctx context.Context, next chan Item, iterable Iterable, opera
 	}()
 }
 
-func runParallel(ctx context.Context, next chan Item, observe <-chan Item, operatorFactory func() operator, bypassGather bool, option Option, opts ...Option) {
+func runParallel(ctx context.Context, next chan Item, observe <-chan Item, operatorFactory func() operator, bypassGather bool, option, stageOption Option, opts ...Option) {
 	wg := sync.WaitGroup{}
 	_, pool := option.getPool()
 	wg.Add(pool)
@@ -324,6 +391,8 @@ func runParallel(ctx context.Context, next chan Item, observe <-chan Item, opera
 				resetIterable: func(newIterable Iterable) {
 					observe = newIterable.Observe(opts...)
 				},
+				name:       stageOption.getName(),
+				deadLetter: stageOption.getDeadLetter(),
 			}
 			for item := range gather {
 				if stopped {
@@ -340,6 +409,15 @@ func runParallel(ctx context.Context, next chan Item, observe <-chan Item, opera
 		}()
 	}
 
+	if option.isPoolOrdered() {
+		runOrderedScatter(ctx, gather, observe, operatorFactory, bypassGather, option, stageOption, opts...)
+		return
+	}
+	if keyed, keyFunc := option.getKeyedPool(); keyed {
+		runKeyedScatter(ctx, gather, dispatchByKey(ctx, observe, pool, keyFunc), operatorFactory, bypassGather, option, stageOption, opts...)
+		return
+	}
+
 	// Scatter
 	for i := 0; i < pool; i++ {
 		go func() {
@@ -354,6 +432,8 @@ func runParallel(ctx context.Context, next chan Item, observe <-chan Item, opera
 				resetIterable: func(newIterable Iterable) {
 					observe = newIterable.Observe(opts...)
 				},
+				name:       stageOption.getName(),
+				deadLetter: stageOption.getDeadLetter(),
 			}
 			defer wg.Done()
 			for !stopped {
@@ -383,7 +463,280 @@ func runParallel(ctx context.Context, next chan Item, observe <-chan Item, opera
 	}()
 }
 
-func runFirstItem(ctx context.Context, f func(interface{}) int, notif chan Item, observe <-chan Item, next chan Item, operatorFactory func() operator, option Option, opts ...Option) {
+// runKeyedScatter is the scatter stage used with a keyed pool. Each worker consumes its own channel.
+func runKeyedScatter(ctx context.Context, gather chan Item, chs []chan Item, operatorFactory func() operator, bypassGather bool, option, stageOption Option, opts ...Option) {
+	wg := sync.WaitGroup{}
+	wg.Add(len(chs))
+
+	for _, ch := range chs {
+		ch := ch
+		go func() {
+			defer wg.Done()
+			op := operatorFactory()
+			stopped := false
+			operator := operatorOptions{
+				stop: func() {
+					if option.getErrorStrategy() == StopOnError {
+						stopped = true
+					}
+				},
+				// The operators resetting the iterable cannot be run in parallel
+				resetIterable: func(Iterable) {},
+				name:          stageOption.getName(),
+				deadLetter:    stageOption.getDeadLetter(),
+			}
+			for !stopped {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-ch:
+					if !ok {
+						if !bypassGather {
+							Of(op).SendContext(ctx, gather)
+						}
+						return
+					}
+					if item.Error() {
+						op.err(ctx, item, gather, operator)
+					} else {
+						op.next(ctx, item, gather, operator)
+					}
+				}
+			}
+			// Once stopped, the remaining items are discarded so that the dispatcher is not blocked
+			for range ch {
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(gather)
+	}()
+}
+
+// checkKeyedPool returns an IllegalInputError if a keyed pool is requested with an invalid configuration.
+func checkKeyedPool(option Option) error {
+	keyed, keyFunc := option.getKeyedPool()
+	if !keyed {
+		return nil
+	}
+	if _, pool := option.getPool(); pool <= 0 {
+		return IllegalInputError{error: "keyed pool size must be positive"}
+	}
+	if keyFunc == nil {
+		return IllegalInputError{error: "keyed pool key function must not be nil"}
+	}
+	return nil
+}
+
+// dispatchByKey distributes the items of a channel to a list of channels so that the items having the same key
+// are always sent to the same channel, in their original order. The errors are sent to the first channel.
+func dispatchByKey(ctx context.Context, observe <-chan Item, pool int, keyFunc func(interface{}) string) []chan Item {
+	chs := make([]chan Item, pool)
+	for i := 0; i < pool; i++ {
+		chs[i] = make(chan Item, 1)
+	}
+
+	go func() {
+		defer func() {
+			for _, ch := range chs {
+				close(ch)
+			}
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				idx := 0
+				if !item.Error() {
+					h := fnv.New32a()
+					_, _ = h.Write([]byte(keyFunc(item.V)))
+					idx = int(h.Sum32() % uint32(pool))
+				}
+				if !item.SendContext(ctx, chs[idx]) {
+					return
+				}
+			}
+		}
+	}()
+
+	return chs
+}
+
+type (
+	// sequencedItem is an item tagged with its position in the source sequence.
+	sequencedItem struct {
+		seq  int
+		item Item
+	}
+
+	// sequencedBatch is the list of items produced by an operator for a given sequence number.
+	// A negative sequence number means the items are not bound to the source sequence (e.g. partial results).
+	sequencedBatch struct {
+		seq   int
+		items []Item
+	}
+
+	// sequenceBoundary is sent by a worker once an item has been processed.
+	sequenceBoundary int
+)
+
+// runOrderedScatter is the scatter stage used with an ordered pool. The source items are tagged with a sequence
+// number, processed by the pool of workers, then re-sequenced before being sent to the gather channel.
+// The number of items in flight is bounded by a reorder window so that a slow item applies backpressure on the source.
+func runOrderedScatter(ctx context.Context, gather chan Item, observe <-chan Item, operatorFactory func() operator, bypassGather bool, option, stageOption Option, opts ...Option) {
+	_, pool := option.getPool()
+	window := make(chan struct{}, 2*pool)
+	sequenced := make(chan sequencedItem)
+	batches := make(chan sequencedBatch)
+	done := make(chan struct{})
+	wg := sync.WaitGroup{}
+	wg.Add(pool)
+
+	// Sequencer
+	go func() {
+		defer close(sequenced)
+		seq := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-done:
+					return
+				case window <- struct{}{}:
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-done:
+					return
+				case sequenced <- sequencedItem{seq: seq, item: item}:
+				}
+				seq++
+			}
+		}
+	}()
+
+	// Scatter
+	for i := 0; i < pool; i++ {
+		results := make(chan Item)
+
+		// Collect the items produced by a worker, per sequence number
+		go func() {
+			defer wg.Done()
+			items := make([]Item, 0)
+			for item := range results {
+				if seq, ok := item.V.(sequenceBoundary); ok {
+					select {
+					case <-ctx.Done():
+					case batches <- sequencedBatch{seq: int(seq), items: items}:
+					}
+					items = make([]Item, 0)
+					continue
+				}
+				items = append(items, item)
+			}
+			if len(items) != 0 {
+				select {
+				case <-ctx.Done():
+				case batches <- sequencedBatch{seq: -1, items: items}:
+				}
+			}
+		}()
+
+		go func() {
+			defer close(results)
+			op := operatorFactory()
+			stopped := false
+			operator := operatorOptions{
+				stop: func() {
+					if option.getErrorStrategy() == StopOnError {
+						stopped = true
+					}
+				},
+				resetIterable: func(newIterable Iterable) {
+					observe = newIterable.Observe(opts...)
+				},
+				name:       stageOption.getName(),
+				deadLetter: stageOption.getDeadLetter(),
+			}
+			for !stopped {
+				select {
+				case <-ctx.Done():
+					return
+				case s, ok := <-sequenced:
+					if !ok {
+						if !bypassGather {
+							Of(op).SendContext(ctx, results)
+						}
+						return
+					}
+					if s.item.Error() {
+						op.err(ctx, s.item, results, operator)
+					} else {
+						op.next(ctx, s.item, results, operator)
+					}
+					if !Of(sequenceBoundary(s.seq)).SendContext(ctx, results) {
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(done)
+		close(batches)
+	}()
+
+	// Reorder
+	go func() {
+		defer close(gather)
+		pending := make(map[int][]Item)
+		partials := make([]Item, 0)
+		nextSeq := 0
+		for batch := range batches {
+			if batch.seq < 0 {
+				partials = append(partials, batch.items...)
+				continue
+			}
+			pending[batch.seq] = batch.items
+			for {
+				items, contains := pending[nextSeq]
+				if !contains {
+					break
+				}
+				delete(pending, nextSeq)
+				for _, item := range items {
+					item.SendContext(ctx, gather)
+				}
+				nextSeq++
+				<-window
+			}
+		}
+		// The pending items following a sequence gap are discarded as the gap is caused by a stopped worker.
+		for _, item := range partials {
+			item.SendContext(ctx, gather)
+		}
+	}()
+}
+
+func runFirstItem(ctx context.Context, f func(interface{}) int, notif chan Item, observe <-chan Item, next chan Item, operatorFactory func() operator, option, stageOption Option, opts ...Option) {
 	go func() {
 		op := operatorFactory()
 		stopped := false
@@ -396,6 +749,8 @@ func runFirstItem(ctx context.Context, f func(interface{}) int, notif chan Item,
 			resetIterable: func(newIterable Iterable) {
 				observe = newIterable.Observe(opts...)
 			},
+			name:       stageOption.getName(),
+			deadLetter: stageOption.getDeadLetter(),
 		}
 
 	loop:
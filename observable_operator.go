@@ -1,9 +1,13 @@
 package rxgo
 
 import (
+	"container/list"
 	"container/ring"
 	"context"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -53,6 +57,67 @@ func (op *allOperator) gatherNext(ctx context.Context, item Item, dst chan<- Ite
 	}
 }
 
+// Aggregate folds the items emitted by an Observable into an accumulator and emits the final accumulator.
+// zero creates an empty accumulator, accumulate folds an item into an accumulator and combine merges two
+// accumulators. If the Observable is empty, the accumulator created by zero is emitted.
+// When run in parallel, each goroutine folds its items into its own accumulator and the accumulators are
+// combined once the Observable completes. Hence, combine must be associative and the order of the items is not
+// preserved. If an error occurs, the accumulator is not emitted, unless ContinueOnError is used.
+func (o *ObservableImpl) Aggregate(zero func() interface{}, accumulate, combine Func2, opts ...Option) Single {
+	errorStrategy := parseOptions(opts...).getErrorStrategy()
+	return single(o.parent, o, func() operator {
+		return &aggregateOperator{
+			accumulate:    accumulate,
+			combine:       combine,
+			acc:           zero(),
+			errorStrategy: errorStrategy,
+		}
+	}, false, false, opts...)
+}
+
+type aggregateOperator struct {
+	accumulate    Func2
+	combine       Func2
+	acc           interface{}
+	errorStrategy OnErrorStrategy
+	errored       bool
+}
+
+func (op *aggregateOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	v, err := op.accumulate(ctx, op.acc, item.V)
+	if err != nil {
+		// With ContinueOnError, the item is skipped and the accumulator is still emitted
+		op.errored = op.errored || op.errorStrategy == StopOnError
+		Error(wrapOperatorError(operatorOptions.name, "Aggregate", item.V, err)).SendContext(ctx, dst)
+		operatorOptions.stop()
+		return
+	}
+	op.acc = v
+}
+
+func (op *aggregateOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	op.errored = op.errored || op.errorStrategy == StopOnError
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *aggregateOperator) end(ctx context.Context, dst chan<- Item) {
+	if !op.errored {
+		Of(op.acc).SendContext(ctx, dst)
+	}
+}
+
+func (op *aggregateOperator) gatherNext(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	partial := item.V.(*aggregateOperator).acc
+	v, err := op.combine(ctx, op.acc, partial)
+	if err != nil {
+		op.errored = op.errored || op.errorStrategy == StopOnError
+		Error(wrapOperatorError(operatorOptions.name, "Aggregate", partial, err)).SendContext(ctx, dst)
+		operatorOptions.stop()
+		return
+	}
+	op.acc = v
+}
+
 // AverageFloat32 calculates the average of numbers emitted by an Observable and emits the average float32.
 func (o *ObservableImpl) AverageFloat32(opts ...Option) Single {
 	return single(o.parent, o, func() operator {
@@ -393,7 +458,7 @@ func (o *ObservableImpl) BackOffRetry(backOffCfg backoff.BackOff, opts ...Option
 
 // BufferWithCount returns an Observable that emits buffers of items it collects
 // from the source Observable.
-// The resulting Observable emits buffers every skip items, each containing a slice of count items.
+// The resulting Observable emits non-overlapping buffers, each containing a slice of count items.
 // When the source Observable completes or encounters an error,
 // the resulting Observable emits the current buffer and propagates
 // the notification from the source Observable.
@@ -439,9 +504,70 @@ func (op *bufferWithCountOperator) end(ctx context.Context, dst chan<- Item) {
 func (op *bufferWithCountOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
+// BufferWithCountSkip returns an Observable that emits buffers of items it collects
+// from the source Observable.
+// The resulting Observable starts a new buffer every skip items, each containing a slice of count items.
+// If skip is lower than count, the buffers overlap. If skip is greater than count, some items are not buffered.
+// When the source Observable completes or encounters an error,
+// the resulting Observable emits the current buffers and propagates
+// the notification from the source Observable.
+func (o *ObservableImpl) BufferWithCountSkip(count, skip int, opts ...Option) Observable {
+	if count <= 0 {
+		return Thrown(IllegalInputError{error: "count must be positive"})
+	}
+	if skip <= 0 {
+		return Thrown(IllegalInputError{error: "skip must be positive"})
+	}
+
+	return observable(o.parent, o, func() operator {
+		return &bufferWithCountSkipOperator{
+			count:   count,
+			skip:    skip,
+			buffers: make([][]interface{}, 0),
+		}
+	}, true, false, opts...)
+}
+
+type bufferWithCountSkipOperator struct {
+	count   int
+	skip    int
+	iCount  int
+	buffers [][]interface{}
+}
+
+func (op *bufferWithCountSkipOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
+	if op.iCount%op.skip == 0 {
+		op.buffers = append(op.buffers, make([]interface{}, 0, op.count))
+	}
+	op.iCount++
+	for i := range op.buffers {
+		op.buffers[i] = append(op.buffers[i], item.V)
+	}
+	// As the buffers are started at different positions, only the oldest one can be full
+	if len(op.buffers) != 0 && len(op.buffers[0]) == op.count {
+		Of(op.buffers[0]).SendContext(ctx, dst)
+		op.buffers = op.buffers[1:]
+	}
+}
+
+func (op *bufferWithCountSkipOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *bufferWithCountSkipOperator) end(ctx context.Context, dst chan<- Item) {
+	for _, buffer := range op.buffers {
+		if len(buffer) != 0 {
+			Of(buffer).SendContext(ctx, dst)
+		}
+	}
+}
+
+func (op *bufferWithCountSkipOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
 // BufferWithTime returns an Observable that emits buffers of items it collects from the source
-// Observable. The resulting Observable starts a new buffer periodically, as determined by the
-// timeshift argument. It emits each buffer after a fixed timespan, specified by the timespan argument.
+// Observable. The resulting Observable emits non-overlapping buffers after a fixed timespan,
+// specified by the timespan argument.
 // When the source Observable completes or encounters an error, the resulting Observable emits
 // the current buffer and propagates the notification from the source Observable.
 func (o *ObservableImpl) BufferWithTime(timespan Duration, opts ...Option) Observable {
@@ -592,86 +718,138 @@ func (o *ObservableImpl) BufferWithTimeOrCount(timespan Duration, count int, opt
 	return customObservableOperator(o.parent, f, opts...)
 }
 
-// Connect instructs a connectable Observable to begin emitting items to its subscribers.
-func (o *ObservableImpl) Connect(ctx context.Context) (context.Context, Disposable) {
-	ctx, cancel := context.WithCancel(ctx)
-	o.Observe(WithContext(ctx), connect())
-	return ctx, Disposable(cancel)
-}
-
-// Contains determines whether an Observable emits a particular item or not.
-func (o *ObservableImpl) Contains(equal Predicate, opts ...Option) Single {
-	return single(o.parent, o, func() operator {
-		return &containsOperator{
-			equal:    equal,
-			contains: false,
-		}
-	}, false, false, opts...)
-}
-
-type containsOperator struct {
-	equal    Predicate
-	contains bool
-}
-
-func (op *containsOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	if op.equal(item.V) {
-		Of(true).SendContext(ctx, dst)
-		op.contains = true
-		operatorOptions.stop()
+// BufferWithTimeShift returns an Observable that emits buffers of items it collects from the source
+// Observable. The resulting Observable starts a new buffer periodically, as determined by the
+// timeshift argument. It emits each buffer after a fixed timespan, specified by the timespan argument.
+// If timeshift is lower than timespan, the buffers overlap. If timeshift is greater than timespan,
+// some items are not buffered. Both durations must be positive.
+// When the source Observable completes or encounters an error, the resulting Observable emits
+// the current buffers and propagates the notification from the source Observable.
+func (o *ObservableImpl) BufferWithTimeShift(timespan, timeshift Duration, opts ...Option) Observable {
+	if timespan == nil || timespan.duration() <= 0 {
+		return Thrown(IllegalInputError{error: "timespan must be positive"})
 	}
-}
-
-func (op *containsOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
-}
-
-func (op *containsOperator) end(ctx context.Context, dst chan<- Item) {
-	if !op.contains {
-		Of(false).SendContext(ctx, dst)
+	if timeshift == nil || timeshift.duration() <= 0 {
+		return Thrown(IllegalInputError{error: "timeshift must be positive"})
 	}
-}
 
-func (op *containsOperator) gatherNext(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	if item.V == true {
-		Of(true).SendContext(ctx, dst)
-		operatorOptions.stop()
-		op.contains = true
+	type timedBuffer struct {
+		items   []interface{}
+		closeAt time.Time
 	}
-}
 
-// Count counts the number of items emitted by the source Observable and emit only this value.
-func (o *ObservableImpl) Count(opts ...Option) Single {
-	return single(o.parent, o, func() operator {
-		return &countOperator{}
-	}, true, false, opts...)
-}
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		defer close(next)
+		observe := o.Observe(opts...)
+		span := timespan.duration()
+		shift := timeshift.duration()
+		now := time.Now()
+		buffers := []*timedBuffer{{items: make([]interface{}, 0), closeAt: now.Add(span)}}
+		nextOpen := now.Add(shift)
+
+		flush := func() {
+			for _, buffer := range buffers {
+				if len(buffer.items) != 0 {
+					if !Of(buffer.items).SendContext(ctx, next) {
+						return
+					}
+				}
+			}
+		}
 
-type countOperator struct {
-	count int64
-}
+		for {
+			// Close the expired buffers first, then open the new ones
+			now := time.Now()
+			for len(buffers) != 0 && !buffers[0].closeAt.After(now) {
+				if len(buffers[0].items) != 0 {
+					if !Of(buffers[0].items).SendContext(ctx, next) {
+						return
+					}
+				}
+				buffers = buffers[1:]
+			}
+			for !nextOpen.After(now) {
+				buffers = append(buffers, &timedBuffer{items: make([]interface{}, 0), closeAt: nextOpen.Add(span)})
+				nextOpen = nextOpen.Add(shift)
+			}
 
-func (op *countOperator) next(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
-	op.count++
-}
+			wait := nextOpen.Sub(now)
+			if len(buffers) != 0 {
+				if d := buffers[0].closeAt.Sub(now); d < wait {
+					wait = d
+				}
+			}
 
-func (op *countOperator) err(_ context.Context, _ Item, _ chan<- Item, operatorOptions operatorOptions) {
-	operatorOptions.stop()
-}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			case item, ok := <-observe:
+				if !ok {
+					flush()
+					return
+				}
+				if item.Error() {
+					if !item.SendContext(ctx, next) {
+						return
+					}
+					if option.getErrorStrategy() == StopOnError {
+						flush()
+						return
+					}
+					continue
+				}
+				for _, buffer := range buffers {
+					buffer.items = append(buffer.items, item.V)
+				}
+			}
+		}
+	}
 
-func (op *countOperator) end(ctx context.Context, dst chan<- Item) {
-	Of(op.count).SendContext(ctx, dst)
+	return customObservableOperator(o.parent, f, opts...)
 }
 
-func (op *countOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
-}
+// BufferToggle returns an Observable that emits buffers of items it collects from the source Observable.
+// A new buffer is started whenever the openings Observable emits an item. The buffer is emitted whenever
+// the Observable returned by the closingSelector for this item emits an item or completes.
+// When the source Observable completes or encounters an error, the resulting Observable emits
+// the current buffers and propagates the notification from the source Observable.
+func (o *ObservableImpl) BufferToggle(openings Observable, closingSelector func(interface{}) Observable, opts ...Option) Observable {
+	if openings == nil {
+		return Thrown(IllegalInputError{error: "openings must no be nil"})
+	}
+	if closingSelector == nil {
+		return Thrown(IllegalInputError{error: "closingSelector must no be nil"})
+	}
+
+	type toggledBuffer struct {
+		id    int
+		items []interface{}
+	}
+	type closing struct {
+		id   int
+		item Item
+	}
 
-// Debounce only emits an item from an Observable if a particular timespan has passed without it emitting another item.
-func (o *ObservableImpl) Debounce(timespan Duration, opts ...Option) Observable {
 	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
 		defer close(next)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
 		observe := o.Observe(opts...)
-		var latest interface{}
+		openingsObserve := openings.Observe(WithContext(ctx))
+		closings := make(chan closing)
+		buffers := make([]*toggledBuffer, 0)
+		id := 0
+
+		flush := func() {
+			for _, buffer := range buffers {
+				if len(buffer.items) != 0 {
+					if !Of(buffer.items).SendContext(ctx, next) {
+						return
+					}
+				}
+			}
+		}
 
 		for {
 			select {
@@ -679,6 +857,7 @@ func (o *ObservableImpl) Debounce(timespan Duration, opts ...Option) Observable
 				return
 			case item, ok := <-observe:
 				if !ok {
+					flush()
 					return
 				}
 				if item.Error() {
@@ -686,17 +865,67 @@ func (o *ObservableImpl) Debounce(timespan Duration, opts ...Option) Observable
 						return
 					}
 					if option.getErrorStrategy() == StopOnError {
+						flush()
 						return
 					}
-				} else {
-					latest = item.V
+					continue
 				}
-			case <-time.After(timespan.duration()):
-				if latest != nil {
-					if !Of(latest).SendContext(ctx, next) {
+				for _, buffer := range buffers {
+					buffer.items = append(buffer.items, item.V)
+				}
+			case item, ok := <-openingsObserve:
+				if !ok {
+					openingsObserve = nil
+					continue
+				}
+				if item.Error() {
+					if !item.SendContext(ctx, next) {
 						return
 					}
-					latest = nil
+					if option.getErrorStrategy() == StopOnError {
+						flush()
+						return
+					}
+					continue
+				}
+				id++
+				buffers = append(buffers, &toggledBuffer{id: id, items: make([]interface{}, 0)})
+				// The closing Observable is disposed once it emitted its first item
+				closingCtx, closingCancel := context.WithCancel(ctx)
+				go func(id int, closingObserve <-chan Item) {
+					defer closingCancel()
+					var item Item
+					select {
+					case <-ctx.Done():
+						return
+					case item = <-closingObserve:
+					}
+					select {
+					case <-ctx.Done():
+					case closings <- closing{id: id, item: item}:
+					}
+				}(id, closingSelector(item.V).Observe(WithContext(closingCtx)))
+			case c := <-closings:
+				if c.item.Error() {
+					if !c.item.SendContext(ctx, next) {
+						return
+					}
+					if option.getErrorStrategy() == StopOnError {
+						flush()
+						return
+					}
+				}
+				for i, buffer := range buffers {
+					if buffer.id != c.id {
+						continue
+					}
+					buffers = append(buffers[:i], buffers[i+1:]...)
+					if len(buffer.items) != 0 {
+						if !Of(buffer.items).SendContext(ctx, next) {
+							return
+						}
+					}
+					break
 				}
 			}
 		}
@@ -705,68 +934,372 @@ func (o *ObservableImpl) Debounce(timespan Duration, opts ...Option) Observable
 	return customObservableOperator(o.parent, f, opts...)
 }
 
-// DefaultIfEmpty returns an Observable that emits the items emitted by the source
-// Observable or a specified default item if the source Observable is empty.
-func (o *ObservableImpl) DefaultIfEmpty(defaultValue interface{}, opts ...Option) Observable {
+// BufferUntil returns an Observable that emits buffers of items it collects from the source Observable.
+// The current buffer is emitted after an item satisfying the predicate, this item being the last one of
+// the buffer.
+// When the source Observable completes or encounters an error, the resulting Observable emits
+// the current buffer and propagates the notification from the source Observable.
+// Cannot be run in parallel.
+func (o *ObservableImpl) BufferUntil(apply Predicate, opts ...Option) Observable {
 	return observable(o.parent, o, func() operator {
-		return &defaultIfEmptyOperator{
-			defaultValue: defaultValue,
-			empty:        true,
+		return &bufferUntilOperator{
+			apply:  apply,
+			buffer: make([]interface{}, 0),
 		}
 	}, true, false, opts...)
 }
 
-type defaultIfEmptyOperator struct {
-	defaultValue interface{}
-	empty        bool
+type bufferUntilOperator struct {
+	apply  Predicate
+	buffer []interface{}
 }
 
-func (op *defaultIfEmptyOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
-	op.empty = false
-	item.SendContext(ctx, dst)
+func (op *bufferUntilOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
+	op.buffer = append(op.buffer, item.V)
+	if op.apply(item.V) {
+		Of(op.buffer).SendContext(ctx, dst)
+		op.buffer = make([]interface{}, 0)
+	}
 }
 
-func (op *defaultIfEmptyOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+func (op *bufferUntilOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
 }
 
-func (op *defaultIfEmptyOperator) end(ctx context.Context, dst chan<- Item) {
-	if op.empty {
-		Of(op.defaultValue).SendContext(ctx, dst)
+func (op *bufferUntilOperator) end(ctx context.Context, dst chan<- Item) {
+	if len(op.buffer) != 0 {
+		Of(op.buffer).SendContext(ctx, dst)
 	}
 }
 
-func (op *defaultIfEmptyOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
-}
-
-// Distinct suppresses duplicate items in the original Observable and returns
-// a new Observable.
-func (o *ObservableImpl) Distinct(apply Func, opts ...Option) Observable {
-	return observable(o.parent, o, func() operator {
-		return &distinctOperator{
-			apply:  apply,
-			keyset: make(map[interface{}]interface{}),
-		}
-	}, false, false, opts...)
+func (op *bufferUntilOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
-type distinctOperator struct {
-	apply  Func
-	keyset map[interface{}]interface{}
-}
+// BufferWhen returns an Observable that emits buffers of items it collects from the source Observable.
+// The current buffer is emitted whenever the boundary Observable emits an item.
+// When the source Observable completes or encounters an error, the resulting Observable emits
+// the current buffer and propagates the notification from the source Observable.
+func (o *ObservableImpl) BufferWhen(boundary Observable, opts ...Option) Observable {
+	if boundary == nil {
+		return Thrown(IllegalInputError{error: "boundary must no be nil"})
+	}
 
-func (op *distinctOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	key, err := op.apply(ctx, item.V)
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		defer close(next)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		observe := o.Observe(opts...)
+		boundaryObserve := boundary.Observe(WithContext(ctx))
+		buffer := make([]interface{}, 0)
+
+		flush := func() bool {
+			if len(buffer) != 0 {
+				if !Of(buffer).SendContext(ctx, next) {
+					return false
+				}
+				buffer = make([]interface{}, 0)
+			}
+			return true
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					flush()
+					return
+				}
+				if item.Error() {
+					if !item.SendContext(ctx, next) {
+						return
+					}
+					if option.getErrorStrategy() == StopOnError {
+						flush()
+						return
+					}
+					continue
+				}
+				buffer = append(buffer, item.V)
+			case item, ok := <-boundaryObserve:
+				if !ok {
+					// The current buffer is emitted once the source Observable completes
+					boundaryObserve = nil
+					continue
+				}
+				if item.Error() {
+					if !item.SendContext(ctx, next) {
+						return
+					}
+					if option.getErrorStrategy() == StopOnError {
+						flush()
+						return
+					}
+					continue
+				}
+				if !flush() {
+					return
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(o.parent, f, opts...)
+}
+
+// Connect instructs a connectable Observable to begin emitting items to its subscribers.
+func (o *ObservableImpl) Connect(ctx context.Context) (context.Context, Disposable) {
+	ctx, cancel := context.WithCancel(ctx)
+	o.Observe(WithContext(ctx), connect())
+	return ctx, Disposable(cancel)
+}
+
+// Contains determines whether an Observable emits a particular item or not.
+func (o *ObservableImpl) Contains(equal Predicate, opts ...Option) Single {
+	return single(o.parent, o, func() operator {
+		return &containsOperator{
+			equal:    equal,
+			contains: false,
+		}
+	}, false, false, opts...)
+}
+
+type containsOperator struct {
+	equal    Predicate
+	contains bool
+}
+
+func (op *containsOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	if op.equal(item.V) {
+		Of(true).SendContext(ctx, dst)
+		op.contains = true
+		operatorOptions.stop()
+	}
+}
+
+func (op *containsOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *containsOperator) end(ctx context.Context, dst chan<- Item) {
+	if !op.contains {
+		Of(false).SendContext(ctx, dst)
+	}
+}
+
+func (op *containsOperator) gatherNext(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	if item.V == true {
+		Of(true).SendContext(ctx, dst)
+		operatorOptions.stop()
+		op.contains = true
+	}
+}
+
+// Count counts the number of items emitted by the source Observable and emit only this value.
+func (o *ObservableImpl) Count(opts ...Option) Single {
+	return single(o.parent, o, func() operator {
+		return &countOperator{}
+	}, true, false, opts...)
+}
+
+type countOperator struct {
+	count int64
+}
+
+func (op *countOperator) next(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+	op.count++
+}
+
+func (op *countOperator) err(_ context.Context, _ Item, _ chan<- Item, operatorOptions operatorOptions) {
+	operatorOptions.stop()
+}
+
+func (op *countOperator) end(ctx context.Context, dst chan<- Item) {
+	Of(op.count).SendContext(ctx, dst)
+}
+
+func (op *countOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+// CountByKey counts the items emitted by an Observable per key, the key being computed by a key selector,
+// and emits a map[interface{}]int.
+func (o *ObservableImpl) CountByKey(keySelector Func, opts ...Option) Single {
+	return single(o.parent, o, func() operator {
+		return &countByKeyOperator{
+			keySelector: keySelector,
+			counts:      make(map[interface{}]int),
+		}
+	}, false, false, opts...)
+}
+
+// CountByKeyWithTime counts the items emitted by an Observable per key within timed windows and emits
+// a map[interface{}]int per window.
+func (o *ObservableImpl) CountByKeyWithTime(timespan Duration, keySelector Func, opts ...Option) Observable {
+	return o.aggregateWithTime(timespan, func(window Observable) Iterable {
+		return window.CountByKey(keySelector)
+	}, opts...)
+}
+
+type countByKeyOperator struct {
+	keySelector Func
+	counts      map[interface{}]int
+}
+
+func (op *countByKeyOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	k, err := op.keySelector(ctx, item.V)
 	if err != nil {
-		Error(err).SendContext(ctx, dst)
+		Error(wrapOperatorError(operatorOptions.name, "CountByKey", item.V, err)).SendContext(ctx, dst)
 		operatorOptions.stop()
 		return
 	}
-	_, ok := op.keyset[key]
-	if !ok {
+	op.counts[k]++
+}
+
+func (op *countByKeyOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *countByKeyOperator) end(ctx context.Context, dst chan<- Item) {
+	Of(op.counts).SendContext(ctx, dst)
+}
+
+func (op *countByKeyOperator) gatherNext(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
+	for k, count := range item.V.(*countByKeyOperator).counts {
+		op.counts[k] += count
+	}
+}
+
+// Debounce only emits an item from an Observable if a particular timespan has passed without it emitting another item.
+func (o *ObservableImpl) Debounce(timespan Duration, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		defer close(next)
+		observe := o.Observe(opts...)
+		var latest interface{}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				if item.Error() {
+					if !item.SendContext(ctx, next) {
+						return
+					}
+					if option.getErrorStrategy() == StopOnError {
+						return
+					}
+				} else {
+					latest = item.V
+				}
+			case <-time.After(timespan.duration()):
+				if latest != nil {
+					if !Of(latest).SendContext(ctx, next) {
+						return
+					}
+					latest = nil
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(o.parent, f, opts...)
+}
+
+// DefaultIfEmpty returns an Observable that emits the items emitted by the source
+// Observable or a specified default item if the source Observable is empty.
+func (o *ObservableImpl) DefaultIfEmpty(defaultValue interface{}, opts ...Option) Observable {
+	return observable(o.parent, o, func() operator {
+		return &defaultIfEmptyOperator{
+			defaultValue: defaultValue,
+			empty:        true,
+		}
+	}, true, false, opts...)
+}
+
+type defaultIfEmptyOperator struct {
+	defaultValue interface{}
+	empty        bool
+}
+
+func (op *defaultIfEmptyOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
+	op.empty = false
+	item.SendContext(ctx, dst)
+}
+
+func (op *defaultIfEmptyOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *defaultIfEmptyOperator) end(ctx context.Context, dst chan<- Item) {
+	if op.empty {
+		Of(op.defaultValue).SendContext(ctx, dst)
+	}
+}
+
+func (op *defaultIfEmptyOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+// Distinct suppresses duplicate items in the original Observable and returns
+// a new Observable.
+// By default, every key is kept. The state can be bounded using WithDistinctTTL, WithDistinctLRU
+// or WithDistinctBloomFilter.
+// In parallel, the keys are computed by the pool while the dedup state is shared.
+func (o *ObservableImpl) Distinct(apply Func, opts ...Option) Observable {
+	option := parseOptions(opts...)
+	// The dedup state is built again for each observation, this one only validates the options
+	if _, err := option.buildKeySet(); err != nil {
+		return Thrown(err)
+	}
+	parallel, _ := option.getPool()
+	return observable(o.parent, o, func() operator {
+		return &distinctOperator{
+			apply:    apply,
+			option:   option,
+			parallel: parallel,
+		}
+	}, false, false, opts...)
+}
+
+type distinctOperator struct {
+	apply    Func
+	option   Option
+	keyset   keySet
+	parallel bool
+}
+
+// keys lazily builds the dedup state as it is not required by the parallel workers.
+func (op *distinctOperator) keys() keySet {
+	if op.keyset == nil {
+		// The options are validated when the operator is created
+		op.keyset, _ = op.option.buildKeySet()
+	}
+	return op.keyset
+}
+
+// distinctKey is sent by a parallel distinct operator to the gather stage.
+type distinctKey struct {
+	key   interface{}
+	value interface{}
+}
+
+func (op *distinctOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	key, err := op.apply(ctx, item.V)
+	if err != nil {
+		Error(wrapOperatorError(operatorOptions.name, "Distinct", item.V, err)).SendContext(ctx, dst)
+		operatorOptions.stop()
+		return
+	}
+	if op.parallel {
+		Of(distinctKey{key: key, value: item.V}).SendContext(ctx, dst)
+		return
+	}
+	if !op.keys().add(key) {
 		item.SendContext(ctx, dst)
 	}
-	op.keyset[key] = nil
 }
 
 func (op *distinctOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
@@ -777,14 +1310,13 @@ func (op *distinctOperator) end(_ context.Context, _ chan<- Item) {
 }
 
 func (op *distinctOperator) gatherNext(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
-	switch item.V.(type) {
-	case *distinctOperator:
+	v, ok := item.V.(distinctKey)
+	if !ok {
 		return
 	}
 
-	if _, contains := op.keyset[item.V]; !contains {
-		Of(item.V).SendContext(ctx, dst)
-		op.keyset[item.V] = nil
+	if !op.keys().add(v.key) {
+		Of(v.value).SendContext(ctx, dst)
 	}
 }
 
@@ -806,7 +1338,7 @@ type distinctUntilChangedOperator struct {
 func (op *distinctUntilChangedOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	key, err := op.apply(ctx, item.V)
 	if err != nil {
-		Error(err).SendContext(ctx, dst)
+		Error(wrapOperatorError(operatorOptions.name, "DistinctUntilChanged", item.V, err)).SendContext(ctx, dst)
 		operatorOptions.stop()
 		return
 	}
@@ -881,29 +1413,82 @@ func (o *ObservableImpl) DoOnError(errFunc ErrFunc, opts ...Option) Disposed {
 }
 
 // DoOnNext registers a callback action that will be called on each item emitted by the Observable.
+// With WithKeyedPool, the callback is called in parallel while preserving the order per key.
 func (o *ObservableImpl) DoOnNext(nextFunc NextFunc, opts ...Option) Disposed {
 	dispose := make(chan struct{})
-	handler := func(ctx context.Context, src <-chan Item) {
-		defer close(dispose)
+	// handle calls the callback with an item. It returns false if the item is an error, stopping the observation.
+	handle := func(i Item) bool {
+		if i.Error() {
+			return false
+		}
+		nextFunc(i.V)
+		return true
+	}
+	// handler returns whether the source emitted an error
+	handler := func(ctx context.Context, src <-chan Item) bool {
 		for {
 			select {
 			case <-ctx.Done():
-				return
+				return false
 			case i, ok := <-src:
 				if !ok {
-					return
+					return false
 				}
-				if i.Error() {
-					return
+				if !handle(i) {
+					return true
 				}
-				nextFunc(i.V)
 			}
 		}
 	}
 
 	option := parseOptions(opts...)
 	ctx := option.buildContext(o.parent)
-	go handler(ctx, o.Observe(opts...))
+	if err := checkKeyedPool(option); err != nil {
+		// The callback is never called as the error stops the observation
+		go func() {
+			defer close(dispose)
+			handler(ctx, Thrown(err).Observe())
+		}()
+		return dispose
+	}
+	if keyed, keyFunc := option.getKeyedPool(); keyed {
+		_, pool := option.getPool()
+		ctx, cancel := context.WithCancel(ctx)
+		wg := sync.WaitGroup{}
+		wg.Add(pool)
+		for _, ch := range dispatchByKey(ctx, o.Observe(opts...), pool, keyFunc) {
+			ch := ch
+			go func() {
+				defer wg.Done()
+				if handler(ctx, ch) {
+					// Stop the other goroutines in case of an error
+					cancel()
+				}
+				for range ch {
+				}
+			}()
+		}
+		go func() {
+			defer close(dispose)
+			wg.Wait()
+			cancel()
+		}()
+		return dispose
+	}
+
+	if fused, ok := o.iterable.(*fusedIterable); ok {
+		// The callback is called from the goroutine of the fused operators
+		go func() {
+			defer close(dispose)
+			fused.forEach(handle, opts...)
+		}()
+		return dispose
+	}
+
+	go func() {
+		defer close(dispose)
+		handler(ctx, o.Observe(opts...))
+	}()
 	return dispose
 }
 
@@ -968,7 +1553,151 @@ func (o *ObservableImpl) Error(opts ...Option) error {
 	}
 }
 
+// TimeWindow is the item type emitted by the EventTimeWindow operator.
+type TimeWindow struct {
+	// Start is the inclusive start of the window
+	Start time.Time
+	// End is the exclusive end of the window
+	End time.Time
+	// Items are the window items, in arrival order
+	Items []interface{}
+}
+
+// EventTimeWindow buffers the items into windows based on the time extracted from each item (the event time)
+// rather than the time they are received. If slide is nil, the windows are tumbling windows of the given size;
+// otherwise, a new window starts every slide and the windows overlap if slide is lower than size.
+// The windows are aligned on the Unix epoch.
+//
+// The watermark is the latest event time seen minus the bound set with WithMaxOutOfOrderness (zero by default).
+// A window is emitted as a TimeWindow once the watermark passes its end. As the watermark only advances with
+// the items, the pending windows are emitted when the source Observable completes or encounters an error.
+// An item arriving after the watermark passed all its windows is late: it is routed to the sink set with
+// WithLateItems or dropped. If slide is greater than size, the items falling between two windows are ignored.
+// Cannot be run in parallel.
+func (o *ObservableImpl) EventTimeWindow(timeExtractor func(interface{}) time.Time, size Duration, slide Duration, opts ...Option) Observable {
+	if timeExtractor == nil {
+		return Thrown(IllegalInputError{error: "timeExtractor must no be nil"})
+	}
+	if size == nil || size.duration() <= 0 {
+		return Thrown(IllegalInputError{error: "size must be positive"})
+	}
+	if slide == nil {
+		slide = size
+	}
+	if slide.duration() <= 0 {
+		return Thrown(IllegalInputError{error: "slide must be positive"})
+	}
+
+	option := parseOptions(opts...)
+	return observable(o.parent, o, func() operator {
+		return &eventTimeWindowOperator{
+			timeExtractor:     timeExtractor,
+			size:              int64(size.duration()),
+			slide:             int64(slide.duration()),
+			maxOutOfOrderness: int64(option.getMaxOutOfOrderness()),
+			lateItems:         option.getLateItems(),
+			windows:           make(map[int64]*TimeWindow),
+			starts: binaryheap.NewWith(func(a, b interface{}) int {
+				switch {
+				case a.(int64) < b.(int64):
+					return -1
+				case a.(int64) > b.(int64):
+					return 1
+				}
+				return 0
+			}),
+		}
+	}, true, false, opts...)
+}
+
+type eventTimeWindowOperator struct {
+	timeExtractor     func(interface{}) time.Time
+	size              int64
+	slide             int64
+	maxOutOfOrderness int64
+	lateItems         chan<- Item
+	started           bool
+	watermark         int64
+	// Pending windows indexed by start
+	windows map[int64]*TimeWindow
+	// Starts of the pending windows, the lowest first
+	starts *binaryheap.Heap
+}
+
+func (op *eventTimeWindowOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
+	ts := op.timeExtractor(item.V).UnixNano()
+	if wm := ts - op.maxOutOfOrderness; !op.started || wm > op.watermark {
+		op.watermark = wm
+		op.started = true
+	}
+
+	// The latest window containing the item starts at the aligned event time
+	last := ts - floorMod(ts, op.slide)
+	if last+op.size <= ts {
+		// With a slide greater than the size, the item falls between two windows: it is not late but ignored
+		op.fire(ctx, dst, op.watermark)
+		return
+	}
+	if last+op.size <= op.watermark {
+		if op.lateItems != nil {
+			item.SendContext(ctx, op.lateItems)
+		}
+		return
+	}
+	for start := last; start+op.size > ts; start -= op.slide {
+		if start+op.size <= op.watermark {
+			// The older windows have already been emitted
+			break
+		}
+		window, exists := op.windows[start]
+		if !exists {
+			window = &TimeWindow{
+				Start: time.Unix(0, start),
+				End:   time.Unix(0, start+op.size),
+				Items: make([]interface{}, 0),
+			}
+			op.windows[start] = window
+			op.starts.Push(start)
+		}
+		window.Items = append(window.Items, item.V)
+	}
+
+	op.fire(ctx, dst, op.watermark)
+}
+
+// fire emits the pending windows whose end is before or equal to the watermark.
+func (op *eventTimeWindowOperator) fire(ctx context.Context, dst chan<- Item, watermark int64) {
+	for {
+		v, ok := op.starts.Peek()
+		if !ok {
+			return
+		}
+		start := v.(int64)
+		if start+op.size > watermark {
+			return
+		}
+		op.starts.Pop()
+		window := op.windows[start]
+		delete(op.windows, start)
+		if !Of(*window).SendContext(ctx, dst) {
+			return
+		}
+	}
+}
+
+func (op *eventTimeWindowOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *eventTimeWindowOperator) end(ctx context.Context, dst chan<- Item) {
+	op.fire(ctx, dst, math.MaxInt64)
+}
+
+func (op *eventTimeWindowOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
 // Errors returns an eventual list of Observable errors.
+// A CompositeError is flattened into its causes.
 // This method is blocking
 func (o *ObservableImpl) Errors(opts ...Option) []error {
 	option := parseOptions(opts...)
@@ -985,7 +1714,11 @@ func (o *ObservableImpl) Errors(opts ...Option) []error {
 				return errs
 			}
 			if item.Error() {
-				errs = append(errs, item.E)
+				if composite, ok := item.E.(CompositeError); ok {
+					errs = append(errs, composite.Errs...)
+				} else {
+					errs = append(errs, item.E)
+				}
 			}
 		}
 	}
@@ -993,22 +1726,29 @@ func (o *ObservableImpl) Errors(opts ...Option) []error {
 
 // Filter emits only those items from an Observable that pass a predicate test.
 func (o *ObservableImpl) Filter(apply Predicate, opts ...Option) Observable {
-	return observable(o.parent, o, func() operator {
+	return fusedObservable(o.parent, o, func() operator {
 		return &filterOperator{apply: apply}
-	}, false, true, opts...)
+	}, opts...)
 }
 
 type filterOperator struct {
 	apply Predicate
 }
 
-func (op *filterOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
-	if op.apply(item.V) {
-		item.SendContext(ctx, dst)
+func (op *filterOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	if res, ok := op.process(ctx, item, operatorOptions); ok {
+		res.SendContext(ctx, dst)
 	}
 }
 
+func (op *filterOperator) process(_ context.Context, item Item, _ operatorOptions) (Item, bool) {
+	return item, op.apply(item.V)
+}
+
 func (op *filterOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	if sendDeadLetter(ctx, operatorOptions.deadLetter, nil, item.E) {
+		return
+	}
 	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
 }
 
@@ -1127,17 +1867,20 @@ func (o *ObservableImpl) FlatMap(apply ItemToObservable, opts ...Option) Observa
 					select {
 					case <-ctx.Done():
 						return
-					case item, ok := <-observe2:
+					case item2, ok := <-observe2:
 						if !ok {
 							break loop2
 						}
-						if item.Error() {
-							item.SendContext(ctx, next)
+						if item2.Error() {
+							if sendDeadLetter(ctx, option.getDeadLetter(), item.V, item2.E) {
+								continue
+							}
+							item2.SendContext(ctx, next)
 							if option.getErrorStrategy() == StopOnError {
 								return
 							}
 						} else {
-							if !item.SendContext(ctx, next) {
+							if !item2.SendContext(ctx, next) {
 								return
 							}
 						}
@@ -1174,12 +1917,69 @@ func (o *ObservableImpl) ForEach(nextFunc NextFunc, errFunc ErrFunc, completedFu
 		}
 	}
 
-	ctx := o.parent
-	if ctx == nil {
-		ctx = context.Background()
+	ctx := o.parent
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	go handler(ctx, o.Observe(opts...))
+	return dispose
+}
+
+// HistogramCounts is the item type emitted by the Histogram operator.
+type HistogramCounts struct {
+	// UpperBounds are the inclusive upper bounds of the buckets, in ascending order
+	UpperBounds []float64
+	// Counts holds the number of items per bucket, the last one counting the items above the last bound
+	Counts []int
+}
+
+// Histogram counts the numbers emitted by an Observable per bucket, the buckets being defined by their
+// inclusive upper bounds in ascending order. Any Go numeric type is accepted.
+func (o *ObservableImpl) Histogram(upperBounds []float64, opts ...Option) Single {
+	return single(o.parent, o, func() operator {
+		return &histogramOperator{
+			upperBounds: upperBounds,
+			counts:      make([]int, len(upperBounds)+1),
+		}
+	}, false, false, opts...)
+}
+
+// HistogramWithTime counts the numbers emitted by an Observable per bucket within timed windows and emits a
+// HistogramCounts per window.
+func (o *ObservableImpl) HistogramWithTime(timespan Duration, upperBounds []float64, opts ...Option) Observable {
+	return o.aggregateWithTime(timespan, func(window Observable) Iterable {
+		return window.Histogram(upperBounds)
+	}, opts...)
+}
+
+type histogramOperator struct {
+	upperBounds []float64
+	counts      []int
+}
+
+func (op *histogramOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	v, ok := toFloat64(item.V)
+	if !ok {
+		Error(numericError(item.V)).SendContext(ctx, dst)
+		operatorOptions.stop()
+		return
+	}
+	op.counts[sort.SearchFloat64s(op.upperBounds, v)]++
+}
+
+func (op *histogramOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *histogramOperator) end(ctx context.Context, dst chan<- Item) {
+	Of(HistogramCounts{UpperBounds: op.upperBounds, Counts: op.counts}).SendContext(ctx, dst)
+}
+
+func (op *histogramOperator) gatherNext(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
+	v := item.V.(*histogramOperator)
+	for i, count := range v.counts {
+		op.counts[i] += count
 	}
-	go handler(ctx, o.Observe(opts...))
-	return dispose
 }
 
 // IgnoreElements ignores all items emitted by the source ObservableSource except for the errors.
@@ -1211,6 +2011,15 @@ func abs(n int64) int64 {
 	return (n ^ y) - y
 }
 
+// Returns the modulo of n by d having the sign of d
+func floorMod(n, d int64) int64 {
+	m := n % d
+	if m != 0 && (m < 0) != (d < 0) {
+		m += d
+	}
+	return m
+}
+
 // Join combines items emitted by two Observables whenever an item from one Observable is emitted during
 // a time window defined according to an item emitted by the other Observable.
 // The time is extracted using a timeExtractor function.
@@ -1245,7 +2054,7 @@ func (o *ObservableImpl) Join(joiner Func2, right Observable, timeExtractor func
 					if abs(lTime-rTime) <= windowDuration {
 						i, err := joiner(ctx, lItem.V, rItem.V)
 						if err != nil {
-							Error(err).SendContext(ctx, next)
+							Error(wrapOperatorError(option.getName(), "Join", lItem.V, err)).SendContext(ctx, next)
 							if option.getErrorStrategy() == StopOnError {
 								return
 							}
@@ -1281,7 +2090,7 @@ func (o *ObservableImpl) Join(joiner Func2, right Observable, timeExtractor func
 						if abs(lTime-rTime) <= windowDuration {
 							i, err := joiner(ctx, lItem.V, rItem.V)
 							if err != nil {
-								Error(err).SendContext(ctx, next)
+								Error(wrapOperatorError(option.getName(), "Join", lItem.V, err)).SendContext(ctx, next)
 								if option.getErrorStrategy() == StopOnError {
 									return
 								}
@@ -1301,6 +2110,187 @@ func (o *ObservableImpl) Join(joiner Func2, right Observable, timeExtractor func
 	return customObservableOperator(o.parent, f, opts...)
 }
 
+// JoinByKey combines the items emitted by two Observables having the same key, the keys being computed by a
+// key selector for each side. The keys must be comparable.
+// Each item is retained for the window duration after its arrival, nil meaning until both Observables complete.
+// During this time, it is joined with every item of the other side having the same key, the left item being
+// always the first argument of the joiner.
+// With LeftOuterJoin, a left item without match is joined with nil once it is no longer retained. With
+// FullOuterJoin, the same goes for a right item, nil being the first argument of the joiner.
+func (o *ObservableImpl) JoinByKey(joiner Func2, right Observable, leftKey, rightKey func(interface{}) interface{}, joinType JoinType, window Duration, opts ...Option) Observable {
+	if joiner == nil {
+		return Thrown(IllegalInputError{error: "joiner must no be nil"})
+	}
+	if right == nil {
+		return Thrown(IllegalInputError{error: "right must no be nil"})
+	}
+	if leftKey == nil || rightKey == nil {
+		return Thrown(IllegalInputError{error: "key selectors must no be nil"})
+	}
+
+	type joinEntry struct {
+		value    interface{}
+		key      interface{}
+		left     bool
+		matched  bool
+		deadline time.Time
+	}
+
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		defer close(next)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		bounded := window != nil
+		var retention time.Duration
+		if bounded {
+			retention = window.duration()
+		}
+
+		lObserve := o.Observe(opts...)
+		rObserve := right.Observe(WithContext(ctx))
+		// Retained items indexed by key
+		lState := make(map[interface{}][]*joinEntry)
+		rState := make(map[interface{}][]*joinEntry)
+		// Retained items of both sides ordered by arrival, hence by deadline
+		arrivals := list.New()
+
+		join := func(l, r, input interface{}) bool {
+			i, err := joiner(ctx, l, r)
+			if err != nil {
+				Error(wrapOperatorError(option.getName(), "JoinByKey", input, err)).SendContext(ctx, next)
+				return option.getErrorStrategy() != StopOnError
+			}
+			return Of(i).SendContext(ctx, next)
+		}
+
+		// release emits a retained item without match, according to the join type.
+		release := func(e *joinEntry) bool {
+			if e.matched {
+				return true
+			}
+			if e.left && joinType != InnerJoin {
+				return join(e.value, nil, e.value)
+			}
+			if !e.left && joinType == FullOuterJoin {
+				return join(nil, e.value, e.value)
+			}
+			return true
+		}
+
+		expire := func(now time.Time) bool {
+			for arrivals.Len() != 0 {
+				e := arrivals.Front().Value.(*joinEntry)
+				if e.deadline.After(now) {
+					return true
+				}
+				arrivals.Remove(arrivals.Front())
+				state := rState
+				if e.left {
+					state = lState
+				}
+				entries := state[e.key]
+				for i, entry := range entries {
+					if entry == e {
+						entries = append(entries[:i], entries[i+1:]...)
+						break
+					}
+				}
+				if len(entries) == 0 {
+					delete(state, e.key)
+				} else {
+					state[e.key] = entries
+				}
+				if !release(e) {
+					return false
+				}
+			}
+			return true
+		}
+
+		add := func(item Item, left bool) bool {
+			if item.Error() {
+				item.SendContext(ctx, next)
+				return option.getErrorStrategy() != StopOnError
+			}
+			e := &joinEntry{value: item.V, left: left}
+			state, other := lState, rState
+			if left {
+				e.key = leftKey(item.V)
+			} else {
+				e.key = rightKey(item.V)
+				state, other = rState, lState
+			}
+			if bounded {
+				e.deadline = time.Now().Add(retention)
+			}
+			for _, match := range other[e.key] {
+				match.matched = true
+				e.matched = true
+				l, r := e.value, match.value
+				if !left {
+					l, r = match.value, e.value
+				}
+				if !join(l, r, item.V) {
+					return false
+				}
+			}
+			state[e.key] = append(state[e.key], e)
+			arrivals.PushBack(e)
+			return true
+		}
+
+		timer := time.NewTimer(retention)
+		defer timer.Stop()
+
+		for lObserve != nil || rObserve != nil {
+			var expired <-chan time.Time
+			if bounded && arrivals.Len() != 0 {
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(time.Until(arrivals.Front().Value.(*joinEntry).deadline))
+				expired = timer.C
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-expired:
+				if !expire(time.Now()) {
+					return
+				}
+			case item, ok := <-lObserve:
+				if !ok {
+					lObserve = nil
+					continue
+				}
+				if !add(item, true) {
+					return
+				}
+			case item, ok := <-rObserve:
+				if !ok {
+					rObserve = nil
+					continue
+				}
+				if !add(item, false) {
+					return
+				}
+			}
+		}
+
+		for e := arrivals.Front(); e != nil; e = e.Next() {
+			if !release(e.Value.(*joinEntry)) {
+				return
+			}
+		}
+	}
+
+	return customObservableOperator(o.parent, f, opts...)
+}
+
 // GroupBy divides an Observable into a set of Observables that each emit a different group of items from the original Observable, organized by key.
 func (o *ObservableImpl) GroupBy(length int, distribution func(Item) int, opts ...Option) Observable {
 	option := parseOptions(opts...)
@@ -1358,40 +2348,124 @@ type GroupedObservable struct {
 }
 
 // GroupByDynamic divides an Observable into a dynamic set of Observables that each emit GroupedObservable from the original Observable, organized by key.
+// By default, a group is completed once the source Observable completes. A group can also be completed
+// once idle, using WithGroupIdleTimeout, or evicted once a maximum number of groups is reached, using WithMaxGroups.
+// In both cases, a new group is emitted if the key returns.
 func (o *ObservableImpl) GroupByDynamic(distribution func(Item) string, opts ...Option) Observable {
 	option := parseOptions(opts...)
 	next := option.buildChannel()
 	ctx := option.buildContext(o.parent)
-	chs := make(map[string]chan Item)
+	hasIdleTimeout, idleTimeout := option.getGroupIdleTimeout()
+	maxGroups, policy := option.getMaxGroups()
+	groupStrategy := option.getGroupBackPressureStrategy()
+	onDrop := option.getOnDrop()
+
+	type group struct {
+		key      string
+		ch       chan Item
+		lastSeen time.Time
+		// Elements in the activity and the creation ordered lists
+		activity *list.Element
+		created  *list.Element
+	}
+	groups := make(map[string]*group)
+	// Groups ordered by last activity, the front being the least recently used
+	byActivity := list.New()
+	// Groups ordered by creation, the front being the oldest
+	byCreation := list.New()
+
+	closeGroup := func(g *group) {
+		close(g.ch)
+		delete(groups, g.key)
+		byActivity.Remove(g.activity)
+		byCreation.Remove(g.created)
+	}
 
 	go func() {
 		observe := o.Observe(opts...)
+		var timer *time.Timer
+		if hasIdleTimeout {
+			timer = time.NewTimer(idleTimeout)
+			defer timer.Stop()
+		}
 	loop:
 		for {
+			var expired <-chan time.Time
+			if hasIdleTimeout {
+				now := time.Now()
+				for byActivity.Len() != 0 {
+					g := byActivity.Front().Value.(*group)
+					if g.lastSeen.Add(idleTimeout).After(now) {
+						break
+					}
+					closeGroup(g)
+				}
+				if byActivity.Len() != 0 {
+					if !timer.Stop() {
+						select {
+						case <-timer.C:
+						default:
+						}
+					}
+					timer.Reset(byActivity.Front().Value.(*group).lastSeen.Add(idleTimeout).Sub(now))
+					expired = timer.C
+				}
+			}
+
 			select {
 			case <-ctx.Done():
 				break loop
+			case <-expired:
 			case i, ok := <-observe:
 				if !ok {
 					break loop
 				}
 				idx := distribution(i)
-				ch, contains := chs[idx]
+				g, contains := groups[idx]
 				if !contains {
-					ch = option.buildChannel()
-					chs[idx] = ch
+					if maxGroups > 0 && len(groups) >= maxGroups {
+						if policy == EvictOldest {
+							closeGroup(byCreation.Front().Value.(*group))
+						} else {
+							closeGroup(byActivity.Front().Value.(*group))
+						}
+					}
+					g = &group{
+						key: idx,
+						ch:  option.buildGroupChannel(),
+					}
+					observed := g.ch
+					if groupStrategy != Block && groupStrategy != Drop {
+						// The relay applies the strategy once the group buffer is full
+						g.ch = make(chan Item)
+						observed = relayBackpressure(ctx, g.ch, observed, groupStrategy, onDrop)
+					}
+					g.activity = byActivity.PushBack(g)
+					g.created = byCreation.PushBack(g)
+					groups[idx] = g
 					Of(GroupedObservable{
 						Observable: &ObservableImpl{
-							iterable: newChannelIterable(ch),
+							iterable: newChannelIterable(observed),
 						},
 						Key: idx,
 					}).SendContext(ctx, next)
+				} else {
+					byActivity.MoveToBack(g.activity)
+				}
+				if hasIdleTimeout {
+					g.lastSeen = time.Now()
+				}
+				if groupStrategy == Drop {
+					if !i.SendNonBlocking(g.ch) && onDrop != nil {
+						onDrop(i)
+					}
+				} else {
+					i.SendContext(ctx, g.ch)
 				}
-				i.SendContext(ctx, ch)
 			}
 		}
-		for _, ch := range chs {
-			close(ch)
+		for _, g := range groups {
+			close(g.ch)
 		}
 		close(next)
 	}()
@@ -1474,26 +2548,44 @@ func (op *lastOrDefaultOperator) gatherNext(_ context.Context, _ Item, _ chan<-
 
 // Map transforms the items emitted by an Observable by applying a function to each item.
 func (o *ObservableImpl) Map(apply Func, opts ...Option) Observable {
-	return observable(o.parent, o, func() operator {
-		return &mapOperator{apply: apply}
-	}, false, true, opts...)
+	return o.mapAs("Map", apply, opts...)
+}
+
+// mapAs creates a Map operator whose errors are reported as raised by a given operator (e.g. Marshal).
+func (o *ObservableImpl) mapAs(operatorName string, apply Func, opts ...Option) Observable {
+	return fusedObservable(o.parent, o, func() operator {
+		return &mapOperator{apply: apply, operatorName: operatorName}
+	}, opts...)
 }
 
 type mapOperator struct {
-	apply Func
+	apply        Func
+	operatorName string
 }
 
 func (op *mapOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	if res, ok := op.process(ctx, item, operatorOptions); ok {
+		res.SendContext(ctx, dst)
+	}
+}
+
+func (op *mapOperator) process(ctx context.Context, item Item, operatorOptions operatorOptions) (Item, bool) {
 	res, err := op.apply(ctx, item.V)
 	if err != nil {
-		Error(err).SendContext(ctx, dst)
+		err = wrapOperatorError(operatorOptions.name, op.operatorName, item.V, err)
+		if sendDeadLetter(ctx, operatorOptions.deadLetter, item.V, err) {
+			return Item{}, false
+		}
 		operatorOptions.stop()
-		return
+		return Error(err), true
 	}
-	Of(res).SendContext(ctx, dst)
+	return Of(res), true
 }
 
 func (op *mapOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	if sendDeadLetter(ctx, operatorOptions.deadLetter, nil, item.E) {
+		return
+	}
 	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
 }
 
@@ -1510,7 +2602,7 @@ func (op *mapOperator) gatherNext(ctx context.Context, item Item, dst chan<- Ite
 
 // Marshal transforms the items emitted by an Observable by applying a marshalling to each item.
 func (o *ObservableImpl) Marshal(marshaller Marshaller, opts ...Option) Observable {
-	return o.Map(func(_ context.Context, i interface{}) (interface{}, error) {
+	return o.mapAs("Marshal", func(_ context.Context, i interface{}) (interface{}, error) {
 		return marshaller(i)
 	}, opts...)
 }
@@ -1599,6 +2691,71 @@ func (op *minOperator) gatherNext(ctx context.Context, item Item, dst chan<- Ite
 	op.next(ctx, Of(item.V.(*minOperator).max), dst, operatorOptions)
 }
 
+// NumericRange is the item type emitted by the MinMax operator.
+type NumericRange struct {
+	Min float64
+	Max float64
+}
+
+// MinMax determines the minimum and the maximum of the numbers emitted by an Observable in a single pass
+// and emits them as a NumericRange. Any Go numeric type is accepted.
+func (o *ObservableImpl) MinMax(opts ...Option) OptionalSingle {
+	return optionalSingle(o.parent, o, func() operator {
+		return &minMaxOperator{}
+	}, false, false, opts...)
+}
+
+// MinMaxWithTime determines the minimum and the maximum of the numbers emitted by an Observable within timed
+// windows and emits a NumericRange per window.
+func (o *ObservableImpl) MinMaxWithTime(timespan Duration, opts ...Option) Observable {
+	return o.aggregateWithTime(timespan, func(window Observable) Iterable {
+		return window.MinMax()
+	}, opts...)
+}
+
+type minMaxOperator struct {
+	min   float64
+	max   float64
+	count int
+}
+
+func (op *minMaxOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	v, ok := toFloat64(item.V)
+	if !ok {
+		Error(numericError(item.V)).SendContext(ctx, dst)
+		operatorOptions.stop()
+		return
+	}
+	op.add(v, v, 1)
+}
+
+func (op *minMaxOperator) add(min, max float64, count int) {
+	if op.count == 0 || min < op.min {
+		op.min = min
+	}
+	if op.count == 0 || max > op.max {
+		op.max = max
+	}
+	op.count += count
+}
+
+func (op *minMaxOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *minMaxOperator) end(ctx context.Context, dst chan<- Item) {
+	if op.count != 0 {
+		Of(NumericRange{Min: op.min, Max: op.max}).SendContext(ctx, dst)
+	}
+}
+
+func (op *minMaxOperator) gatherNext(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
+	v := item.V.(*minMaxOperator)
+	if v.count != 0 {
+		op.add(v.min, v.max, v.count)
+	}
+}
+
 // Observe observes an Observable by returning its channel.
 func (o *ObservableImpl) Observe(opts ...Option) <-chan Item {
 	return o.iterable.Observe(opts...)
@@ -1681,14 +2838,73 @@ func (op *onErrorReturnItemOperator) end(_ context.Context, _ chan<- Item) {
 func (op *onErrorReturnItemOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
+// Percentiles estimates the values at the given quantiles, between 0 and 1, of the numbers emitted by an
+// Observable and emits them as a []float64 in the same order. Any Go numeric type is accepted.
+// The estimation relies on a t-digest sketch: the memory is bounded and the accuracy is higher close to the
+// extreme quantiles.
+func (o *ObservableImpl) Percentiles(qs []float64, opts ...Option) OptionalSingle {
+	return optionalSingle(o.parent, o, func() operator {
+		return &percentilesOperator{
+			qs:     qs,
+			digest: newTDigest(100),
+		}
+	}, false, false, opts...)
+}
+
+// PercentilesWithTime estimates the values at the given quantiles of the numbers emitted by an Observable
+// within timed windows and emits a []float64 per window.
+func (o *ObservableImpl) PercentilesWithTime(timespan Duration, qs []float64, opts ...Option) Observable {
+	return o.aggregateWithTime(timespan, func(window Observable) Iterable {
+		return window.Percentiles(qs)
+	}, opts...)
+}
+
+type percentilesOperator struct {
+	qs     []float64
+	digest *tDigest
+}
+
+func (op *percentilesOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	v, ok := toFloat64(item.V)
+	if !ok {
+		Error(numericError(item.V)).SendContext(ctx, dst)
+		operatorOptions.stop()
+		return
+	}
+	op.digest.add(v)
+}
+
+func (op *percentilesOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *percentilesOperator) end(ctx context.Context, dst chan<- Item) {
+	if op.digest.count == 0 {
+		return
+	}
+	values := make([]float64, len(op.qs))
+	for i, q := range op.qs {
+		values[i] = op.digest.quantile(q)
+	}
+	Of(values).SendContext(ctx, dst)
+}
+
+func (op *percentilesOperator) gatherNext(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
+	op.digest.merge(item.V.(*percentilesOperator).digest)
+}
+
 // Reduce applies a function to each item emitted by an Observable, sequentially, and emit the final value.
 func (o *ObservableImpl) Reduce(apply Func2, opts ...Option) OptionalSingle {
-	return optionalSingle(o.parent, o, func() operator {
+	operatorFactory := func() operator {
 		return &reduceOperator{
 			apply: apply,
 			empty: true,
 		}
-	}, false, false, opts...)
+	}
+	if parseOptions(opts...).isBatchable() {
+		return &OptionalSingleImpl{parent: o.parent, iterable: newBatchIterable(o.parent, o, operatorFactory, opts...)}
+	}
+	return optionalSingle(o.parent, o, operatorFactory, false, false, opts...)
 }
 
 type reduceOperator struct {
@@ -1701,7 +2917,7 @@ func (op *reduceOperator) next(ctx context.Context, item Item, dst chan<- Item,
 	op.empty = false
 	v, err := op.apply(ctx, op.acc, item.V)
 	if err != nil {
-		Error(err).SendContext(ctx, dst)
+		Error(wrapOperatorError(operatorOptions.name, "Reduce", item.V, err)).SendContext(ctx, dst)
 		operatorOptions.stop()
 		op.empty = true
 		return
@@ -1937,7 +3153,7 @@ type scanOperator struct {
 func (op *scanOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	v, err := op.apply(ctx, op.current, item.V)
 	if err != nil {
-		Error(err).SendContext(ctx, dst)
+		Error(wrapOperatorError(operatorOptions.name, "Scan", item.V, err)).SendContext(ctx, dst)
 		operatorOptions.stop()
 		return
 	}
@@ -2091,44 +3307,194 @@ func (o *ObservableImpl) Serialize(from int, identifier func(interface{}) int, o
 		src := o.Observe(opts...)
 		defer close(next)
 
-		for {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-src:
+				if !ok {
+					return
+				}
+				if item.Error() {
+					next <- item
+					return
+				}
+
+				id := identifier(item.V)
+				minHeap.Push(id)
+				items[id] = item.V
+
+				for !minHeap.Empty() {
+					v, _ := minHeap.Peek()
+					id := v.(int)
+					if atomic.LoadInt64(&counter) == int64(id) {
+						if itemValue, contains := items[id]; contains {
+							minHeap.Pop()
+							delete(items, id)
+							Of(itemValue).SendContext(ctx, next)
+							counter++
+							continue
+						}
+					}
+					break
+				}
+			}
+		}
+	}()
+
+	return &ObservableImpl{
+		iterable: newChannelIterable(next),
+	}
+}
+
+// SessionWindow divides an Observable into session windows per key. A session is a GroupedObservable
+// emitted on the first item of a key and completed once there has been no item for this key during the gap
+// duration. If maxDuration is not nil, a session is also completed once it has lasted for maxDuration, the next
+// item of the same key starting a new session.
+// The open sessions are completed when the source Observable completes or encounters an error, the error
+// being emitted by the resulting Observable.
+// As several sessions may be open at the same time, sending an item to a session never blocks.
+func (o *ObservableImpl) SessionWindow(keyFunc func(Item) string, gap Duration, maxDuration Duration, opts ...Option) Observable {
+	if keyFunc == nil {
+		return Thrown(IllegalInputError{error: "keyFunc must no be nil"})
+	}
+	if gap == nil {
+		return Thrown(IllegalInputError{error: "gap must no be nil"})
+	}
+
+	type session struct {
+		key      string
+		ch       chan<- Item
+		start    time.Time
+		lastSeen time.Time
+		// Elements in the activity and the start ordered lists
+		activity *list.Element
+		started  *list.Element
+	}
+
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		observe := o.Observe(opts...)
+		sessionsCh := unboundedChannel(ctx, next)
+		defer close(sessionsCh)
+		gapDuration := gap.duration()
+		var maxSessionDuration time.Duration
+		if maxDuration != nil {
+			maxSessionDuration = maxDuration.duration()
+		}
+
+		sessions := make(map[string]*session)
+		// Sessions ordered by last activity, the front being the first to become idle
+		byActivity := list.New()
+		// Sessions ordered by start time, the front being the first to reach the max duration
+		byStart := list.New()
+		defer func() {
+			for _, s := range sessions {
+				close(s.ch)
+			}
+		}()
+
+		closeSession := func(s *session) {
+			close(s.ch)
+			delete(sessions, s.key)
+			byActivity.Remove(s.activity)
+			byStart.Remove(s.started)
+		}
+
+		// closeExpired completes the sessions which are idle or have reached the max duration
+		closeExpired := func(now time.Time) {
+			for byActivity.Len() != 0 {
+				s := byActivity.Front().Value.(*session)
+				if s.lastSeen.Add(gapDuration).After(now) {
+					break
+				}
+				closeSession(s)
+			}
+			if maxDuration != nil {
+				for byStart.Len() != 0 {
+					s := byStart.Front().Value.(*session)
+					if s.start.Add(maxSessionDuration).After(now) {
+						break
+					}
+					closeSession(s)
+				}
+			}
+		}
+
+		timer := time.NewTimer(gapDuration)
+		defer timer.Stop()
+
+		for {
+			now := time.Now()
+			closeExpired(now)
+
+			var expired <-chan time.Time
+			if byActivity.Len() != 0 {
+				deadline := byActivity.Front().Value.(*session).lastSeen.Add(gapDuration)
+				if maxDuration != nil {
+					if d := byStart.Front().Value.(*session).start.Add(maxSessionDuration); d.Before(deadline) {
+						deadline = d
+					}
+				}
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(deadline.Sub(now))
+				expired = timer.C
+			}
+
 			select {
 			case <-ctx.Done():
 				return
-			case item, ok := <-src:
+			case <-expired:
+			case item, ok := <-observe:
 				if !ok {
 					return
 				}
 				if item.Error() {
-					next <- item
-					return
+					if !item.SendContext(ctx, sessionsCh) {
+						return
+					}
+					if option.getErrorStrategy() == StopOnError {
+						return
+					}
+					continue
 				}
-
-				id := identifier(item.V)
-				minHeap.Push(id)
-				items[id] = item.V
-
-				for !minHeap.Empty() {
-					v, _ := minHeap.Peek()
-					id := v.(int)
-					if atomic.LoadInt64(&counter) == int64(id) {
-						if itemValue, contains := items[id]; contains {
-							minHeap.Pop()
-							delete(items, id)
-							Of(itemValue).SendContext(ctx, next)
-							counter++
-							continue
-						}
+				// The item may have been received long after the previous iteration
+				now = time.Now()
+				closeExpired(now)
+				key := keyFunc(item)
+				s, contains := sessions[key]
+				if !contains {
+					ch := make(chan Item)
+					s = &session{
+						key:   key,
+						ch:    unboundedChannel(ctx, ch),
+						start: now,
 					}
-					break
+					s.activity = byActivity.PushBack(s)
+					s.started = byStart.PushBack(s)
+					sessions[key] = s
+					if !Of(GroupedObservable{
+						Observable: &ObservableImpl{iterable: newChannelIterable(ch)},
+						Key:        key,
+					}).SendContext(ctx, sessionsCh) {
+						return
+					}
+				} else {
+					byActivity.MoveToBack(s.activity)
+				}
+				s.lastSeen = now
+				if !item.SendContext(ctx, s.ch) {
+					return
 				}
 			}
 		}
-	}()
-
-	return &ObservableImpl{
-		iterable: newChannelIterable(next),
 	}
+
+	return customObservableOperator(o.parent, f, opts...)
 }
 
 // Skip suppresses the first n items in the original Observable and
@@ -2237,6 +3603,160 @@ func (op *skipWhileOperator) end(_ context.Context, _ chan<- Item) {
 func (op *skipWhileOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
+// Sort emits the items emitted by an Observable in the order defined by a comparator, once the Observable
+// completes. The sort is stable. If the Observable emits an error, the sorted items are not emitted, unless
+// ContinueOnError is used.
+// Cannot be run in parallel.
+func (o *ObservableImpl) Sort(comparator Comparator, opts ...Option) Observable {
+	errorStrategy := parseOptions(opts...).getErrorStrategy()
+	return observable(o.parent, o, func() operator {
+		return &sortOperator{
+			comparator:    comparator,
+			errorStrategy: errorStrategy,
+		}
+	}, true, false, opts...)
+}
+
+type sortOperator struct {
+	comparator    Comparator
+	errorStrategy OnErrorStrategy
+	items         []interface{}
+	errored       bool
+}
+
+func (op *sortOperator) next(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
+	op.items = append(op.items, item.V)
+}
+
+func (op *sortOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	// With ContinueOnError, the sorted items are still emitted once the Observable completes
+	op.errored = op.errored || op.errorStrategy == StopOnError
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *sortOperator) end(ctx context.Context, dst chan<- Item) {
+	if op.errored {
+		return
+	}
+	sortItems(op.items, op.comparator)
+	for _, v := range op.items {
+		if !Of(v).SendContext(ctx, dst) {
+			return
+		}
+	}
+}
+
+func (op *sortOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+// SortExternal emits the items emitted by an Observable in the order defined by a comparator, once the
+// Observable completes, without keeping more than memLimit items in memory.
+// Each time memLimit items are buffered, they are sorted and spilled to a temporary file of tmpDir (the default
+// temporary directory if empty), encoded by the codec. The runs are then merged, at most 64 at a time, in as
+// many passes as required. The sort is stable.
+// The temporary files are removed once the operator completes.
+// If the Observable emits an error, the sorted items are not emitted, unless ContinueOnError is used.
+// Cannot be run in parallel.
+func (o *ObservableImpl) SortExternal(comparator Comparator, memLimit int, tmpDir string, codec Codec, opts ...Option) Observable {
+	if memLimit <= 0 {
+		return Thrown(IllegalInputError{error: "memLimit must be positive"})
+	}
+	if codec == nil {
+		return Thrown(IllegalInputError{error: "codec must no be nil"})
+	}
+	errorStrategy := parseOptions(opts...).getErrorStrategy()
+	return observable(o.parent, o, func() operator {
+		return &sortExternalOperator{
+			comparator:    comparator,
+			errorStrategy: errorStrategy,
+			memLimit:      memLimit,
+			fanIn:         sortMaxFanIn,
+			tmpDir:        tmpDir,
+			codec:         codec,
+		}
+	}, true, false, opts...)
+}
+
+type sortExternalOperator struct {
+	comparator    Comparator
+	errorStrategy OnErrorStrategy
+	memLimit      int
+	// Maximum number of runs merged at once
+	fanIn   int
+	tmpDir  string
+	codec   Codec
+	items   []interface{}
+	runs    []*sortRun
+	errored bool
+}
+
+func (op *sortExternalOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	if op.errored {
+		return
+	}
+	op.items = append(op.items, item.V)
+	if len(op.items) < op.memLimit {
+		return
+	}
+	sortItems(op.items, op.comparator)
+	run, err := writeSortRun(op.tmpDir, op.items, op.codec)
+	if err != nil {
+		op.errored = true
+		Error(wrapOperatorError(operatorOptions.name, "SortExternal", item.V, err)).SendContext(ctx, dst)
+		operatorOptions.stop()
+		return
+	}
+	op.runs = append(op.runs, run)
+	op.items = make([]interface{}, 0, op.memLimit)
+}
+
+func (op *sortExternalOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	// With ContinueOnError, the sorted items are still emitted once the Observable completes
+	op.errored = op.errored || op.errorStrategy == StopOnError
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *sortExternalOperator) end(ctx context.Context, dst chan<- Item) {
+	defer func() {
+		for _, run := range op.runs {
+			run.remove()
+		}
+	}()
+	if op.errored {
+		return
+	}
+	// The runs are merged in passes to bound the number of open files
+	runs, err := reduceSortRuns(op.tmpDir, op.runs, op.fanIn, op.comparator, op.codec)
+	op.runs = runs
+	if err != nil {
+		Error(err).SendContext(ctx, dst)
+		return
+	}
+	// The remaining items are merged from memory
+	sortItems(op.items, op.comparator)
+	merger, err := newSortMerger(op.runs, op.items, op.comparator, op.codec)
+	if err != nil {
+		Error(err).SendContext(ctx, dst)
+		return
+	}
+	for {
+		v, ok, err := merger.next()
+		if err != nil {
+			Error(err).SendContext(ctx, dst)
+			return
+		}
+		if !ok {
+			return
+		}
+		if !Of(v).SendContext(ctx, dst) {
+			return
+		}
+	}
+}
+
+func (op *sortExternalOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
 // StartWith emits a specified Iterable before beginning to emit the items from the source Observable.
 func (o *ObservableImpl) StartWith(iterable Iterable, opts ...Option) Observable {
 	option := parseOptions(opts...)
@@ -2286,6 +3806,22 @@ func (o *ObservableImpl) StartWith(iterable Iterable, opts ...Option) Observable
 	}
 }
 
+// StdDev calculates the population standard deviation of the numbers emitted by an Observable and emits
+// a float64. Any Go numeric type is accepted.
+func (o *ObservableImpl) StdDev(opts ...Option) Single {
+	return single(o.parent, o, func() operator {
+		return &varianceOperator{stdDev: true}
+	}, false, false, opts...)
+}
+
+// StdDevWithTime calculates the population standard deviation of the numbers emitted by an Observable
+// within timed windows and emits a float64 per window.
+func (o *ObservableImpl) StdDevWithTime(timespan Duration, opts ...Option) Observable {
+	return o.aggregateWithTime(timespan, func(window Observable) Iterable {
+		return window.StdDev()
+	}, opts...)
+}
+
 // SumFloat32 calculates the average of float32 emitted by an Observable and emits a float32.
 func (o *ObservableImpl) SumFloat32(opts ...Option) OptionalSingle {
 	return o.Reduce(func(_ context.Context, acc, elem interface{}) (interface{}, error) {
@@ -2575,6 +4111,62 @@ func (op *timestampOperator) end(_ context.Context, _ chan<- Item) {
 func (op *timestampOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
+// ToFlowable converts an Observable into a Flowable. The strategy defines what to do with the items emitted
+// by the Observable while there is no demand: block the Observable, drop the items, keep the latest item only,
+// or buffer the items (up to the WithBufferedChannel capacity) and either drop the oldest one or emit a
+// BufferOverflowError once the buffer is full. The dropped items are passed to the WithOnDrop callback.
+func (o *ObservableImpl) ToFlowable(strategy BackpressureStrategy, opts ...Option) Flowable {
+	return &FlowableImpl{
+		subscribe: func(propagatedOptions ...Option) (<-chan Item, Subscription) {
+			mergedOptions := append(opts, propagatedOptions...)
+			option := parseOptions(mergedOptions...)
+			next := option.buildChannel()
+			sub := newSubscription(option.buildContext(emptyContext))
+			onDrop := option.getOnDrop()
+			observe := o.Observe(append(append([]Option{}, mergedOptions...), WithContext(sub.ctx))...)
+			if strategy != Block && strategy != Drop {
+				// The relay buffers the items while the demand is awaited
+				observe = relayBackpressure(sub.ctx, observe, option.buildChannel(), strategy, onDrop)
+			}
+
+			go func() {
+				defer close(next)
+				defer sub.Cancel()
+				for {
+					select {
+					case <-sub.ctx.Done():
+						return
+					case item, ok := <-observe:
+						if !ok {
+							return
+						}
+						if !item.Error() {
+							switch strategy {
+							case Drop:
+								if !sub.tryConsume() {
+									if onDrop != nil {
+										onDrop(item)
+									}
+									continue
+								}
+							default:
+								if sub.awaitDemand() == 0 {
+									return
+								}
+								sub.tryConsume()
+							}
+						}
+						if !item.SendContext(sub.ctx, next) {
+							return
+						}
+					}
+				}
+			}()
+			return next, sub
+		},
+	}
+}
+
 // ToMap convert the sequence of items emitted by an Observable
 // into a map keyed by a specified key function.
 // Cannot be run in parallel.
@@ -2595,7 +4187,7 @@ type toMapOperator struct {
 func (op *toMapOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	k, err := op.keySelector(ctx, item.V)
 	if err != nil {
-		Error(err).SendContext(ctx, dst)
+		Error(wrapOperatorError(operatorOptions.name, "ToMap", item.V, err)).SendContext(ctx, dst)
 		operatorOptions.stop()
 		return
 	}
@@ -2632,77 +4224,417 @@ type toMapWithValueSelector struct {
 	m                          map[interface{}]interface{}
 }
 
-func (op *toMapWithValueSelector) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+func (op *toMapWithValueSelector) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	k, err := op.keySelector(ctx, item.V)
+	if err != nil {
+		Error(wrapOperatorError(operatorOptions.name, "ToMapWithValueSelector", item.V, err)).SendContext(ctx, dst)
+		operatorOptions.stop()
+		return
+	}
+
+	v, err := op.valueSelector(ctx, item.V)
+	if err != nil {
+		Error(wrapOperatorError(operatorOptions.name, "ToMapWithValueSelector", item.V, err)).SendContext(ctx, dst)
+		operatorOptions.stop()
+		return
+	}
+
+	op.m[k] = v
+}
+
+func (op *toMapWithValueSelector) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *toMapWithValueSelector) end(ctx context.Context, dst chan<- Item) {
+	Of(op.m).SendContext(ctx, dst)
+}
+
+func (op *toMapWithValueSelector) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+// ToSlice collects all items from an Observable and emit them in a slice and an optional error.
+// Cannot be run in parallel.
+func (o *ObservableImpl) ToSlice(initialCapacity int, opts ...Option) ([]interface{}, error) {
+	op := &toSliceOperator{
+		s: make([]interface{}, 0, initialCapacity),
+	}
+	<-observable(o.parent, o, func() operator {
+		return op
+	}, true, false, opts...).Run()
+	return op.s, op.observableErr
+}
+
+type toSliceOperator struct {
+	s             []interface{}
+	observableErr error
+}
+
+func (op *toSliceOperator) next(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
+	op.s = append(op.s, item.V)
+}
+
+func (op *toSliceOperator) err(_ context.Context, item Item, _ chan<- Item, operatorOptions operatorOptions) {
+	op.observableErr = item.E
+	operatorOptions.stop()
+}
+
+func (op *toSliceOperator) end(_ context.Context, _ chan<- Item) {
+}
+
+func (op *toSliceOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+// KeyCount is the item type emitted by the TopK operator.
+type KeyCount struct {
+	Key   interface{}
+	Count int
+	// Error is the maximum overestimation of Count, always zero unless the count is approximate
+	Error int
+}
+
+// TopK counts the items emitted by an Observable per key, the key being computed by a key selector, and emits
+// the k keys having the highest counts as a []KeyCount, in descending order of count. The ties are broken by
+// key order so that the result does not depend on the arrival order: numbers and strings are compared by value,
+// the other keys by their string representation.
+// By default, every key is counted. With WithTopKSpaceSaving, the memory is bounded but the counts are
+// approximate; in this case, the operator cannot be run in parallel.
+func (o *ObservableImpl) TopK(k int, keySelector Func, opts ...Option) Single {
+	capacity := parseOptions(opts...).getTopKSpaceSaving()
+	return single(o.parent, o, func() operator {
+		if capacity > 0 {
+			return &topKSpaceSavingOperator{
+				k:           k,
+				keySelector: keySelector,
+				summary:     newSpaceSaving(capacity),
+			}
+		}
+		return &topKOperator{
+			k:           k,
+			keySelector: keySelector,
+			counts:      make(map[interface{}]*topKCount),
+		}
+	}, capacity > 0, false, opts...)
+}
+
+// TopKWithTime emits the k keys having the highest counts within timed windows, as a []KeyCount per window.
+func (o *ObservableImpl) TopKWithTime(timespan Duration, k int, keySelector Func, opts ...Option) Observable {
+	capacity := parseOptions(opts...).getTopKSpaceSaving()
+	return o.aggregateWithTime(timespan, func(window Observable) Iterable {
+		if capacity > 0 {
+			return window.TopK(k, keySelector, WithTopKSpaceSaving(capacity))
+		}
+		return window.TopK(k, keySelector)
+	}, opts...)
+}
+
+type topKOperator struct {
+	k           int
+	keySelector Func
+	counts      map[interface{}]*topKCount
+}
+
+type topKCount struct {
+	key   interface{}
+	count int
+}
+
+// compareKeys orders two keys: numbers and strings are compared by value, the other keys by their type and
+// string representation.
+func compareKeys(a, b interface{}) int {
+	if fa, ok := toFloat64(a); ok {
+		if fb, ok := toFloat64(b); ok {
+			switch {
+			case fa < fb:
+				return -1
+			case fa > fb:
+				return 1
+			}
+		}
+	}
+	if sa, ok := a.(string); ok {
+		if sb, ok := b.(string); ok {
+			return strings.Compare(sa, sb)
+		}
+	}
+	return strings.Compare(fmt.Sprintf("%T:%v", a, a), fmt.Sprintf("%T:%v", b, b))
+}
+
+func (op *topKOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	k, err := op.keySelector(ctx, item.V)
+	if err != nil {
+		Error(wrapOperatorError(operatorOptions.name, "TopK", item.V, err)).SendContext(ctx, dst)
+		operatorOptions.stop()
+		return
+	}
+	op.add(k, 1)
+}
+
+func (op *topKOperator) add(key interface{}, count int) {
+	c, exists := op.counts[key]
+	if !exists {
+		op.counts[key] = &topKCount{key: key, count: count}
+		return
+	}
+	c.count += count
+}
+
+func (op *topKOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *topKOperator) end(ctx context.Context, dst chan<- Item) {
+	// Min-heap of the k best keys so far, the root being the worst one
+	minHeap := binaryheap.NewWith(func(a, b interface{}) int {
+		c1, c2 := a.(*topKCount), b.(*topKCount)
+		if c1.count != c2.count {
+			return c1.count - c2.count
+		}
+		return compareKeys(c2.key, c1.key)
+	})
+	for _, c := range op.counts {
+		minHeap.Push(c)
+		if minHeap.Size() > op.k {
+			minHeap.Pop()
+		}
+	}
+	result := make([]KeyCount, minHeap.Size())
+	for i := len(result) - 1; i >= 0; i-- {
+		v, _ := minHeap.Pop()
+		c := v.(*topKCount)
+		result[i] = KeyCount{Key: c.key, Count: c.count}
+	}
+	Of(result).SendContext(ctx, dst)
+}
+
+func (op *topKOperator) gatherNext(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
+	for _, c := range item.V.(*topKOperator).counts {
+		op.add(c.key, c.count)
+	}
+}
+
+type topKSpaceSavingOperator struct {
+	k           int
+	keySelector Func
+	summary     *spaceSaving
+}
+
+func (op *topKSpaceSavingOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	k, err := op.keySelector(ctx, item.V)
 	if err != nil {
-		Error(err).SendContext(ctx, dst)
+		Error(wrapOperatorError(operatorOptions.name, "TopK", item.V, err)).SendContext(ctx, dst)
 		operatorOptions.stop()
 		return
 	}
+	op.summary.add(k)
+}
 
-	v, err := op.valueSelector(ctx, item.V)
-	if err != nil {
-		Error(err).SendContext(ctx, dst)
-		operatorOptions.stop()
-		return
-	}
+func (op *topKSpaceSavingOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
 
-	op.m[k] = v
+func (op *topKSpaceSavingOperator) end(ctx context.Context, dst chan<- Item) {
+	Of(op.summary.top(op.k)).SendContext(ctx, dst)
 }
 
-func (op *toMapWithValueSelector) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+func (op *topKSpaceSavingOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
-func (op *toMapWithValueSelector) end(ctx context.Context, dst chan<- Item) {
-	Of(op.m).SendContext(ctx, dst)
+// Unmarshal transforms the items emitted by an Observable by applying an unmarshalling to each item.
+func (o *ObservableImpl) Unmarshal(unmarshaller Unmarshaller, factory func() interface{}, opts ...Option) Observable {
+	return o.mapAs("Unmarshal", func(_ context.Context, i interface{}) (interface{}, error) {
+		v := factory()
+		err := unmarshaller(i.([]byte), v)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	}, opts...)
 }
 
-func (op *toMapWithValueSelector) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+// Variance calculates the population variance of the numbers emitted by an Observable and emits a float64.
+// Any Go numeric type is accepted.
+func (o *ObservableImpl) Variance(opts ...Option) Single {
+	return single(o.parent, o, func() operator {
+		return &varianceOperator{}
+	}, false, false, opts...)
 }
 
-// ToSlice collects all items from an Observable and emit them in a slice and an optional error.
-// Cannot be run in parallel.
-func (o *ObservableImpl) ToSlice(initialCapacity int, opts ...Option) ([]interface{}, error) {
-	op := &toSliceOperator{
-		s: make([]interface{}, 0, initialCapacity),
-	}
-	<-observable(o.parent, o, func() operator {
-		return op
-	}, true, false, opts...).Run()
-	return op.s, op.observableErr
+// VarianceWithTime calculates the population variance of the numbers emitted by an Observable within timed
+// windows and emits a float64 per window.
+func (o *ObservableImpl) VarianceWithTime(timespan Duration, opts ...Option) Observable {
+	return o.aggregateWithTime(timespan, func(window Observable) Iterable {
+		return window.Variance()
+	}, opts...)
 }
 
-type toSliceOperator struct {
-	s             []interface{}
-	observableErr error
+// varianceOperator computes the variance in a single pass using Welford's algorithm.
+type varianceOperator struct {
+	stdDev bool
+	count  float64
+	mean   float64
+	// Sum of the squared differences from the mean
+	m2 float64
 }
 
-func (op *toSliceOperator) next(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
-	op.s = append(op.s, item.V)
+func (op *varianceOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	v, ok := toFloat64(item.V)
+	if !ok {
+		Error(numericError(item.V)).SendContext(ctx, dst)
+		operatorOptions.stop()
+		return
+	}
+	op.count++
+	delta := v - op.mean
+	op.mean += delta / op.count
+	op.m2 += delta * (v - op.mean)
 }
 
-func (op *toSliceOperator) err(_ context.Context, item Item, _ chan<- Item, operatorOptions operatorOptions) {
-	op.observableErr = item.E
-	operatorOptions.stop()
+func (op *varianceOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
 }
 
-func (op *toSliceOperator) end(_ context.Context, _ chan<- Item) {
+func (op *varianceOperator) end(ctx context.Context, dst chan<- Item) {
+	variance := 0.
+	if op.count != 0 {
+		variance = op.m2 / op.count
+	}
+	if op.stdDev {
+		Of(math.Sqrt(variance)).SendContext(ctx, dst)
+	} else {
+		Of(variance).SendContext(ctx, dst)
+	}
 }
 
-func (op *toSliceOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+func (op *varianceOperator) gatherNext(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
+	// Parallel variant of Welford's algorithm, merging the partial states
+	v := item.V.(*varianceOperator)
+	if v.count == 0 {
+		return
+	}
+	count := op.count + v.count
+	delta := v.mean - op.mean
+	op.mean += delta * v.count / count
+	op.m2 += v.m2 + delta*delta*op.count*v.count/count
+	op.count = count
 }
 
-// Unmarshal transforms the items emitted by an Observable by applying an unmarshalling to each item.
-func (o *ObservableImpl) Unmarshal(unmarshaller Unmarshaller, factory func() interface{}, opts ...Option) Observable {
-	return o.Map(func(_ context.Context, i interface{}) (interface{}, error) {
-		v := factory()
-		err := unmarshaller(i.([]byte), v)
-		if err != nil {
-			return nil, err
+// aggregateWithTime applies an aggregation to each timed window of an Observable and emits the results,
+// an empty result being skipped.
+func (o *ObservableImpl) aggregateWithTime(timespan Duration, aggregate func(window Observable) Iterable, opts ...Option) Observable {
+	if timespan == nil {
+		return Thrown(IllegalInputError{error: "timespan must no be nil"})
+	}
+
+	windows := o.WindowWithTime(timespan, opts...)
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		defer close(next)
+		observe := windows.Observe(opts...)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				if item.Error() {
+					item.SendContext(ctx, next)
+					if option.getErrorStrategy() == StopOnError {
+						return
+					}
+					continue
+				}
+				// The aggregation is drained as it may emit after an error
+				var result Item
+				empty := true
+				for i := range aggregate(item.V.(Observable)).Observe() {
+					if empty {
+						result = i
+						empty = false
+					}
+				}
+				if empty {
+					continue
+				}
+				if !result.SendContext(ctx, next) {
+					return
+				}
+				if result.Error() && option.getErrorStrategy() == StopOnError {
+					return
+				}
+			}
 		}
-		return v, nil
-	}, opts...)
+	}
+
+	return customObservableOperator(o.parent, f, opts...)
+}
+
+// WindowWhen subdivides items from an Observable into Observable windows, the current window being completed
+// whenever the boundary Observable emits an item, and emit these windows rather than emitting the items one at a time.
+func (o *ObservableImpl) WindowWhen(boundary Observable, opts ...Option) Observable {
+	if boundary == nil {
+		return Thrown(IllegalInputError{error: "boundary must no be nil"})
+	}
+
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		defer close(next)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		observe := o.Observe(opts...)
+		boundaryObserve := boundary.Observe(WithContext(ctx))
+		ch := option.buildChannel()
+		defer func() {
+			close(ch)
+		}()
+		empty := true
+		if !Of(FromChannel(ch)).SendContext(ctx, next) {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				if !item.SendContext(ctx, ch) {
+					return
+				}
+				empty = false
+				if item.Error() && option.getErrorStrategy() == StopOnError {
+					return
+				}
+			case item, ok := <-boundaryObserve:
+				if !ok {
+					boundaryObserve = nil
+					continue
+				}
+				if item.Error() {
+					if !item.SendContext(ctx, ch) {
+						return
+					}
+					if option.getErrorStrategy() == StopOnError {
+						return
+					}
+					continue
+				}
+				if empty {
+					continue
+				}
+				close(ch)
+				empty = true
+				ch = option.buildChannel()
+				if !Of(FromChannel(ch)).SendContext(ctx, next) {
+					return
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(o.parent, f, opts...)
 }
 
 // WindowWithCount periodically subdivides items from an Observable into Observable windows of a given size and emit these windows
@@ -2770,6 +4702,83 @@ func (op *windowWithCountOperator) end(_ context.Context, _ chan<- Item) {
 func (op *windowWithCountOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
+// WindowWithCountSkip subdivides items from an Observable into Observable windows of a given size, starting
+// a new window every skip items, and emit these windows rather than emitting the items one at a time.
+// If skip is lower than count, the windows overlap. If skip is greater than count, some items are not emitted.
+// As several windows may be open at the same time, sending an item to a window never blocks. The errors are
+// sent to every open window but are not counted in the window size.
+func (o *ObservableImpl) WindowWithCountSkip(count, skip int, opts ...Option) Observable {
+	if count <= 0 {
+		return Thrown(IllegalInputError{error: "count must be positive"})
+	}
+	if skip <= 0 {
+		return Thrown(IllegalInputError{error: "skip must be positive"})
+	}
+
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		observe := o.Observe(opts...)
+		windows := unboundedChannel(ctx, next)
+		defer close(windows)
+		// Each window is backed by an unbounded channel so that a slow window never blocks the other ones
+		open := make([]chan<- Item, 0)
+		sizes := make([]int, 0)
+		iCount := 0
+		defer func() {
+			for _, ch := range open {
+				close(ch)
+			}
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				if item.Error() {
+					for _, ch := range open {
+						if !item.SendContext(ctx, ch) {
+							return
+						}
+					}
+					if len(open) == 0 {
+						item.SendContext(ctx, windows)
+					}
+					if option.getErrorStrategy() == StopOnError {
+						return
+					}
+					continue
+				}
+				if iCount%skip == 0 {
+					ch := make(chan Item)
+					open = append(open, unboundedChannel(ctx, ch))
+					sizes = append(sizes, 0)
+					if !Of(FromChannel(ch)).SendContext(ctx, windows) {
+						return
+					}
+				}
+				iCount++
+				for i, ch := range open {
+					if !item.SendContext(ctx, ch) {
+						return
+					}
+					sizes[i]++
+				}
+				// As the windows are started at different positions, only the oldest one can be full
+				if len(open) != 0 && sizes[0] == count {
+					close(open[0])
+					open = open[1:]
+					sizes = sizes[1:]
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(o.parent, f, opts...)
+}
+
 // WindowWithTime periodically subdivides items from an Observable into Observables based on timed windows
 // and emit them rather than emitting the items one at a time.
 func (o *ObservableImpl) WindowWithTime(timespan Duration, opts ...Option) Observable {
@@ -2955,6 +4964,124 @@ func (o *ObservableImpl) WindowWithTimeOrCount(timespan Duration, count int, opt
 	return customObservableOperator(o.parent, f, opts...)
 }
 
+// WindowWithTimeShift subdivides items from an Observable into Observables based on timed windows: a new window
+// is started periodically, as determined by the timeshift argument, and each window is completed after a fixed
+// timespan, specified by the timespan argument.
+// If timeshift is lower than timespan, the windows overlap. If timeshift is greater than timespan,
+// some items are not emitted. Both durations must be positive.
+// As several windows may be open at the same time, sending an item to a window never blocks.
+func (o *ObservableImpl) WindowWithTimeShift(timespan, timeshift Duration, opts ...Option) Observable {
+	if timespan == nil || timespan.duration() <= 0 {
+		return Thrown(IllegalInputError{error: "timespan must be positive"})
+	}
+	if timeshift == nil || timeshift.duration() <= 0 {
+		return Thrown(IllegalInputError{error: "timeshift must be positive"})
+	}
+
+	type timedWindow struct {
+		ch      chan<- Item
+		closeAt time.Time
+	}
+
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		observe := o.Observe(opts...)
+		windows := unboundedChannel(ctx, next)
+		defer close(windows)
+		span := timespan.duration()
+		shift := timeshift.duration()
+		open := make([]timedWindow, 0)
+		defer func() {
+			for _, window := range open {
+				close(window.ch)
+			}
+		}()
+
+		openWindow := func(closeAt time.Time) bool {
+			ch := make(chan Item)
+			open = append(open, timedWindow{ch: unboundedChannel(ctx, ch), closeAt: closeAt})
+			return Of(FromChannel(ch)).SendContext(ctx, windows)
+		}
+
+		now := time.Now()
+		if !openWindow(now.Add(span)) {
+			return
+		}
+		nextOpen := now.Add(shift)
+
+		for {
+			// Close the expired windows first, then open the new ones
+			now := time.Now()
+			for len(open) != 0 && !open[0].closeAt.After(now) {
+				close(open[0].ch)
+				open = open[1:]
+			}
+			for !nextOpen.After(now) {
+				if !openWindow(nextOpen.Add(span)) {
+					return
+				}
+				nextOpen = nextOpen.Add(shift)
+			}
+
+			wait := nextOpen.Sub(now)
+			if len(open) != 0 {
+				if d := open[0].closeAt.Sub(now); d < wait {
+					wait = d
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				for _, window := range open {
+					item.SendContext(ctx, window.ch)
+				}
+				if item.Error() && option.getErrorStrategy() == StopOnError {
+					return
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(o.parent, f, opts...)
+}
+
+// unboundedChannel returns a channel relaying the items to an output channel without ever blocking the sender.
+// The output channel is closed once the returned channel is closed and every item relayed.
+func unboundedChannel(ctx context.Context, out chan<- Item) chan<- Item {
+	in := make(chan Item)
+	go func() {
+		defer close(out)
+		queue := make([]Item, 0)
+		input := in
+		for input != nil || len(queue) != 0 {
+			var output chan<- Item
+			var head Item
+			if len(queue) != 0 {
+				output = out
+				head = queue[0]
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-input:
+				if !ok {
+					input = nil
+					continue
+				}
+				queue = append(queue, item)
+			case output <- head:
+				queue = queue[1:]
+			}
+		}
+	}()
+	return in
+}
+
 // ZipFromIterable merges the emissions of an Iterable via a specified function
 // and emit single items for each combination based on the results of this function.
 func (o *ObservableImpl) ZipFromIterable(iterable Iterable, zipper Func2, opts ...Option) Observable {
@@ -2993,7 +5120,7 @@ func (o *ObservableImpl) ZipFromIterable(iterable Iterable, zipper Func2, opts .
 						}
 						v, err := zipper(ctx, i1.V, i2.V)
 						if err != nil {
-							Error(err).SendContext(ctx, next)
+							Error(wrapOperatorError(option.getName(), "ZipFromIterable", i1.V, err)).SendContext(ctx, next)
 							return
 						}
 						Of(v).SendContext(ctx, next)
@@ -101,6 +101,24 @@ func Benchmark_Reduce_Parallel(b *testing.B) {
 	}
 }
 
+func Benchmark_Aggregate_Parallel(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		obs := Range(0, benchNumberOfElementsSmall, WithBufferedChannel(benchChannelCap)).
+			Aggregate(func() interface{} {
+				return 0
+			}, func(_ context.Context, acc, elem interface{}) (interface{}, error) {
+				// Simulate a blocking IO call
+				time.Sleep(5 * time.Millisecond)
+				return acc.(int) + elem.(int), nil
+			}, func(_ context.Context, acc1, acc2 interface{}) (interface{}, error) {
+				return acc1.(int) + acc2.(int), nil
+			}, WithPool(ioPool))
+		b.StartTimer()
+		<-obs.Run()
+	}
+}
+
 func Benchmark_Map_Sequential(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		b.StopTimer()
@@ -128,3 +146,126 @@ func Benchmark_Map_Parallel(b *testing.B) {
 		<-obs.Run()
 	}
 }
+
+const benchNumberOfElementsLarge = 100000
+
+func benchIncrement(_ context.Context, i interface{}) (interface{}, error) {
+	return i.(int) + 1, nil
+}
+
+func benchIsEven(i interface{}) bool {
+	return i.(int)%2 == 0
+}
+
+func Benchmark_MapFilter_Fused(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		obs := Range(0, benchNumberOfElementsLarge).
+			Map(benchIncrement).
+			Filter(benchIsEven).
+			Map(benchIncrement).
+			Filter(benchIsEven)
+		b.StartTimer()
+		<-obs.Run()
+	}
+}
+
+func Benchmark_MapFilter_NotFused(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		// Each operator runs in its own goroutine
+		var obs Observable = Range(0, benchNumberOfElementsLarge)
+		for j := 0; j < 2; j++ {
+			obs = observable(nil, obs, func() operator {
+				return &mapOperator{apply: benchIncrement}
+			}, false, true)
+			obs = observable(nil, obs, func() operator {
+				return &filterOperator{apply: benchIsEven}
+			}, false, true)
+		}
+		b.StartTimer()
+		<-obs.Run()
+	}
+}
+
+const benchBatchSize = 256
+
+func benchBatchTransport() []Option {
+	// The first and last operators still exchange the items one by one with Range and Run
+	return []Option{WithBatchTransport(benchBatchSize, WithDuration(time.Millisecond)), WithBufferedChannel(benchBatchSize)}
+}
+
+func benchSum(_ context.Context, acc, elem interface{}) (interface{}, error) {
+	if acc == nil {
+		return elem, nil
+	}
+	return acc.(int) + elem.(int), nil
+}
+
+func Benchmark_Map_PerItem(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		obs := Range(0, benchNumberOfElementsLarge).
+			Map(benchIncrement, WithBufferedChannel(benchBatchSize)).
+			Map(benchIncrement, WithBufferedChannel(benchBatchSize)).
+			Map(benchIncrement, WithBufferedChannel(benchBatchSize))
+		b.StartTimer()
+		<-obs.Run()
+	}
+}
+
+func Benchmark_Map_BatchTransport(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		obs := Range(0, benchNumberOfElementsLarge).
+			Map(benchIncrement, benchBatchTransport()...).
+			Map(benchIncrement, benchBatchTransport()...).
+			Map(benchIncrement, benchBatchTransport()...)
+		b.StartTimer()
+		<-obs.Run()
+	}
+}
+
+func Benchmark_Filter_PerItem(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		obs := Range(0, benchNumberOfElementsLarge).
+			Map(benchIncrement, WithBufferedChannel(benchBatchSize)).
+			Filter(benchIsEven, WithBufferedChannel(benchBatchSize))
+		b.StartTimer()
+		<-obs.Run()
+	}
+}
+
+func Benchmark_Filter_BatchTransport(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		obs := Range(0, benchNumberOfElementsLarge).
+			Map(benchIncrement, benchBatchTransport()...).
+			Filter(benchIsEven, benchBatchTransport()...)
+		b.StartTimer()
+		<-obs.Run()
+	}
+}
+
+func Benchmark_Reduce_PerItem(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		obs := Range(0, benchNumberOfElementsLarge).
+			Map(benchIncrement, WithBufferedChannel(benchBatchSize)).
+			Reduce(benchSum, WithBufferedChannel(benchBatchSize))
+		b.StartTimer()
+		<-obs.Run()
+	}
+}
+
+func Benchmark_Reduce_BatchTransport(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		obs := Range(0, benchNumberOfElementsLarge).
+			Map(benchIncrement, benchBatchTransport()...).
+			Reduce(benchSum, benchBatchTransport()...)
+		b.StartTimer()
+		<-obs.Run()
+	}
+}
@@ -2,7 +2,13 @@ package rxgo
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/goleak"
@@ -136,3 +142,346 @@ func Test_Observable_Option_Serialize_Error(t *testing.T) {
 	}))
 	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
 }
+
+func Test_Observable_Option_WithName(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			if i == 2 {
+				return nil, errFoo
+			}
+			return i, nil
+		}, WithName("enrich"))
+	Assert(ctx, t, obs, HasItems(1), HasError(OperatorError{
+		Name:     "enrich",
+		Operator: "Map",
+		Value:    2,
+		Err:      errFoo,
+	}))
+}
+
+func Test_Observable_Option_WithName_Unwrap(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := testObservable(ctx, 1, 2, 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return nil, errFoo
+		}, WithName("enrich")).Error()
+	assert.True(t, errors.Is(err, errFoo))
+	var operatorErr OperatorError
+	assert.True(t, errors.As(err, &operatorErr))
+	assert.Equal(t, "enrich", operatorErr.Name)
+	assert.Equal(t, 1, operatorErr.Value)
+	assert.Equal(t, "enrich: Map(1): foo", err.Error())
+}
+
+func Test_Observable_Option_WithName_NotInherited(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return nil, errFoo
+		}).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithName("second"))
+	Assert(ctx, t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_Option_WithName_Marshal(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1).
+		Marshal(func(interface{}) ([]byte, error) {
+			return nil, errFoo
+		}, WithName("encode"))
+	Assert(ctx, t, obs, IsEmpty(), HasError(OperatorError{
+		Name:     "encode",
+		Operator: "Marshal",
+		Value:    1,
+		Err:      errFoo,
+	}))
+}
+
+func Test_Observable_Option_WithName_Unmarshal(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, []byte("a")).
+		Unmarshal(func([]byte, interface{}) error {
+			return errFoo
+		}, func() interface{} {
+			return new(string)
+		}, WithName("decode"))
+	Assert(ctx, t, obs, IsEmpty(), HasError(OperatorError{
+		Name:     "decode",
+		Operator: "Unmarshal",
+		Value:    []byte("a"),
+		Err:      errFoo,
+	}))
+}
+
+func Test_Observable_Option_WithName_Parallel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return nil, errFoo
+		}, WithName("enrich"), WithCPUPool())
+	Assert(ctx, t, obs, IsEmpty(), HasError(OperatorError{
+		Name:     "enrich",
+		Operator: "Map",
+		Value:    1,
+		Err:      errFoo,
+	}))
+}
+
+func Test_Observable_Option_WithDeadLetter_Map(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	deadLetter := make(chan Item, 3)
+	obs := testObservable(ctx, 1, 2, 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			if i == 2 {
+				return nil, errFoo
+			}
+			return i, nil
+		}, WithDeadLetter(deadLetter))
+	Assert(ctx, t, obs, HasItems(1, 3), HasNoError())
+	close(deadLetter)
+	Assert(ctx, t, FromChannel(deadLetter), HasItems(DeadLetter{V: 2, E: errFoo}))
+}
+
+func Test_Observable_Option_WithDeadLetter_StopOnError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	deadLetter := make(chan Item, 3)
+	// The routed errors do not stop the operator
+	obs := testObservable(ctx, 1, 2, 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			if i != 3 {
+				return nil, errFoo
+			}
+			return i, nil
+		}, WithDeadLetter(deadLetter), WithErrorStrategy(StopOnError))
+	Assert(ctx, t, obs, HasItems(3), HasNoError())
+	close(deadLetter)
+	Assert(ctx, t, FromChannel(deadLetter), HasItems(DeadLetter{V: 1, E: errFoo}, DeadLetter{V: 2, E: errFoo}))
+}
+
+func Test_Observable_Option_WithDeadLetter_Unmarshal(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	deadLetter := make(chan Item, 3)
+	obs := testObservable(ctx, []byte(`{"id":1}`), []byte(`{"id":`), []byte(`{"id":3}`)).
+		Unmarshal(json.Unmarshal, func() interface{} {
+			return &testStruct{}
+		}, WithDeadLetter(deadLetter))
+	Assert(ctx, t, obs, HasItems(&testStruct{ID: 1}, &testStruct{ID: 3}), HasNoError())
+	close(deadLetter)
+	letter := (<-deadLetter).V.(DeadLetter)
+	assert.Equal(t, []byte(`{"id":`), letter.V)
+	assert.Error(t, letter.E)
+}
+
+func Test_Observable_Option_WithDeadLetter_Filter(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	deadLetter := make(chan Item, 3)
+	obs := testObservable(ctx, 1, errFoo, 2, 3).
+		Filter(func(i interface{}) bool {
+			return i != 2
+		}, WithDeadLetter(deadLetter))
+	Assert(ctx, t, obs, HasItems(1, 3), HasNoError())
+	close(deadLetter)
+	Assert(ctx, t, FromChannel(deadLetter), HasItems(DeadLetter{E: errFoo}))
+}
+
+func Test_Observable_Option_WithDeadLetter_FlatMap(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	deadLetter := make(chan Item, 3)
+	obs := testObservable(ctx, 1, 2, 3).
+		FlatMap(func(i Item) Observable {
+			if i.V == 2 {
+				return Thrown(errFoo)
+			}
+			return Just(i.V, i.V.(int)*10)()
+		}, WithDeadLetter(deadLetter))
+	Assert(ctx, t, obs, HasItems(1, 10, 3, 30), HasNoError())
+	close(deadLetter)
+	Assert(ctx, t, FromChannel(deadLetter), HasItems(DeadLetter{V: 2, E: errFoo}))
+}
+
+func Test_Observable_Option_WithDeadLetter_NotInherited(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	deadLetter := make(chan Item, 3)
+	obs := testObservable(ctx, 1, 2, 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return nil, errFoo
+		}).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithDeadLetter(deadLetter))
+	Assert(ctx, t, obs, IsEmpty(), HasNoError())
+	close(deadLetter)
+	Assert(ctx, t, FromChannel(deadLetter), HasItems(DeadLetter{E: errFoo}))
+}
+
+func Test_Observable_Option_WithOrderedPool_Map(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	const n = 100
+	expected := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		expected = append(expected, i*10)
+	}
+	obs := Range(0, n).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		time.Sleep(time.Duration(rand.Intn(100)) * time.Microsecond)
+		return i.(int) * 10, nil
+	}, WithOrderedPool(8), WithContext(ctx))
+	Assert(ctx, t, obs, HasItems(expected...), HasNoError())
+}
+
+func Test_Observable_Option_WithOrderedPool_Filter(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := Range(0, 10).Filter(func(i interface{}) bool {
+		time.Sleep(time.Duration(rand.Intn(100)) * time.Microsecond)
+		return i.(int)%2 == 0
+	}, WithOrderedPool(4), WithContext(ctx))
+	Assert(ctx, t, obs, HasItems(0, 2, 4, 6, 8), HasNoError())
+}
+
+func Test_Observable_Option_WithOrderedPool_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, 3).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		if i == 2 {
+			return nil, errFoo
+		}
+		return i, nil
+	}, WithOrderedPool(1), WithContext(ctx))
+	Assert(ctx, t, obs, HasItems(1), HasError(errFoo))
+}
+
+func Test_Observable_Option_WithOrderedPool_Gather(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := Range(1, 100).Max(func(a, b interface{}) int {
+		return a.(int) - b.(int)
+	}, WithOrderedPool(4), WithContext(ctx))
+	Assert(ctx, t, obs, HasItem(100), HasNoError())
+}
+
+type keyedTestItem struct {
+	key string
+	seq int
+}
+
+func keyedTestObservable(keys, n int) Observable {
+	items := make([]interface{}, 0, keys*n)
+	for i := 0; i < n; i++ {
+		for k := 0; k < keys; k++ {
+			items = append(items, keyedTestItem{key: fmt.Sprintf("key-%d", k), seq: i})
+		}
+	}
+	return Just(items...)()
+}
+
+func assertOrderedPerKey(t *testing.T, items []interface{}) {
+	t.Helper()
+	latest := make(map[string]int)
+	for _, item := range items {
+		v := item.(keyedTestItem)
+		if seq, contains := latest[v.key]; contains {
+			assert.Equal(t, seq+1, v.seq, "key %s", v.key)
+		}
+		latest[v.key] = v.seq
+	}
+}
+
+func Test_Observable_Option_WithKeyedPool_Map(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	items, err := keyedTestObservable(10, 20).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		time.Sleep(time.Duration(rand.Intn(100)) * time.Microsecond)
+		return i, nil
+	}, WithKeyedPool(4, func(i interface{}) string {
+		return i.(keyedTestItem).key
+	}), WithContext(ctx)).ToSlice(0)
+	assert.NoError(t, err)
+	assert.Len(t, items, 200)
+	assertOrderedPerKey(t, items)
+}
+
+func Test_Observable_Option_WithKeyedPool_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, 3).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		if i == 2 {
+			return nil, errFoo
+		}
+		return i, nil
+	}, WithKeyedPool(2, func(i interface{}) string {
+		return "same"
+	}), WithContext(ctx))
+	Assert(ctx, t, obs, HasItems(1), HasError(errFoo))
+}
+
+func Test_Observable_Option_WithKeyedPool_InvalidInput(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	keyFunc := func(i interface{}) string {
+		return "same"
+	}
+
+	Assert(ctx, t, testObservable(ctx, 1, 2, 3).Map(identity, WithKeyedPool(0, keyFunc)),
+		IsEmpty(), HasError(IllegalInputError{error: "keyed pool size must be positive"}))
+	Assert(ctx, t, testObservable(ctx, 1, 2, 3).Map(identity, WithKeyedPool(-1, keyFunc)),
+		IsEmpty(), HasError(IllegalInputError{error: "keyed pool size must be positive"}))
+	Assert(ctx, t, testObservable(ctx, 1, 2, 3).Map(identity, WithKeyedPool(2, nil)),
+		IsEmpty(), HasError(IllegalInputError{error: "keyed pool key function must not be nil"}))
+
+	called := false
+	<-testObservable(ctx, 1, 2, 3).DoOnNext(func(interface{}) {
+		called = true
+	}, WithKeyedPool(0, keyFunc))
+	assert.False(t, called)
+}
+
+func Test_Observable_Option_WithKeyedPool_DoOnNext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	mutex := sync.Mutex{}
+	items := make([]interface{}, 0)
+	<-keyedTestObservable(10, 20).DoOnNext(func(i interface{}) {
+		time.Sleep(time.Duration(rand.Intn(100)) * time.Microsecond)
+		mutex.Lock()
+		items = append(items, i)
+		mutex.Unlock()
+	}, WithKeyedPool(4, func(i interface{}) string {
+		return i.(keyedTestItem).key
+	}))
+	assert.Len(t, items, 200)
+	assertOrderedPerKey(t, items)
+}
@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -64,6 +68,94 @@ func Test_Observable_All_Parallel_Error(t *testing.T) {
 		HasError(errFoo))
 }
 
+func sumZero() interface{} {
+	return 0
+}
+
+func sumFunc2(_ context.Context, a, b interface{}) (interface{}, error) {
+	return a.(int) + b.(int), nil
+}
+
+func Test_Observable_Aggregate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, 3, 4).Aggregate(sumZero, sumFunc2, sumFunc2)
+	Assert(ctx, t, obs, HasItem(10))
+}
+
+func Test_Observable_Aggregate_Parallel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := Range(0, 1000).Aggregate(sumZero, sumFunc2, sumFunc2, WithCPUPool())
+	Assert(ctx, t, obs, HasItem(499500))
+}
+
+func Test_Observable_Aggregate_ParallelMap(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := Range(0, 1000).Aggregate(func() interface{} {
+		return make(map[int]int)
+	}, func(_ context.Context, acc, item interface{}) (interface{}, error) {
+		m := acc.(map[int]int)
+		m[item.(int)%2]++
+		return m, nil
+	}, func(_ context.Context, acc1, acc2 interface{}) (interface{}, error) {
+		m := acc1.(map[int]int)
+		for k, v := range acc2.(map[int]int) {
+			m[k] += v
+		}
+		return m, nil
+	}, WithPool(4))
+	Assert(ctx, t, obs, HasItem(map[int]int{0: 500, 1: 500}))
+}
+
+func Test_Observable_Aggregate_Empty(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, Empty().Aggregate(sumZero, sumFunc2, sumFunc2, WithCPUPool()), HasItem(0))
+}
+
+func Test_Observable_Aggregate_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, errFoo, 3).Aggregate(sumZero, sumFunc2, sumFunc2)
+	Assert(ctx, t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_Aggregate_AccumulateError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, 3).Aggregate(sumZero, func(_ context.Context, acc, item interface{}) (interface{}, error) {
+		if item == 2 {
+			return nil, errFoo
+		}
+		return acc.(int) + item.(int), nil
+	}, sumFunc2)
+	Assert(ctx, t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_Aggregate_ContinueOnError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, errFoo, 3).Aggregate(sumZero, sumFunc2, sumFunc2, WithErrorStrategy(ContinueOnError))
+	Assert(ctx, t, obs, HasItem(4), HasError(errFoo))
+
+	obs = testObservable(ctx, 1, 2, 3).Aggregate(sumZero, func(_ context.Context, acc, item interface{}) (interface{}, error) {
+		if item == 2 {
+			return nil, errFoo
+		}
+		return acc.(int) + item.(int), nil
+	}, sumFunc2, WithErrorStrategy(ContinueOnError))
+	Assert(ctx, t, obs, HasItem(4), HasError(errFoo))
+}
+
 func Test_Observable_AverageFloat32(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -239,6 +331,119 @@ func Test_Observable_BackOffRetry_Error(t *testing.T) {
 	Assert(ctx, t, obs, HasItems(1, 2, 1, 2, 1, 2, 1, 2), HasError(errFoo))
 }
 
+func Test_Observable_BufferToggle(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	source := make(chan Item)
+	openings := make(chan Item)
+	closings := []chan Item{make(chan Item), make(chan Item)}
+	observe := FromChannel(source).BufferToggle(FromChannel(openings), func(i interface{}) Observable {
+		return FromChannel(closings[i.(int)])
+	}).Observe()
+
+	openings <- Of(0)
+	source <- Of(1)
+	openings <- Of(1)
+	source <- Of(2)
+	closings[0] <- Of(struct{}{})
+	assert.Equal(t, []interface{}{1, 2}, (<-observe).V)
+	source <- Of(3)
+	close(source)
+	assert.Equal(t, []interface{}{2, 3}, (<-observe).V)
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_BufferToggle_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	source := make(chan Item)
+	openings := make(chan Item)
+	observe := FromChannel(source).BufferToggle(FromChannel(openings), func(i interface{}) Observable {
+		return Never()
+	}).Observe()
+
+	openings <- Of(0)
+	source <- Of(1)
+	source <- Error(errFoo)
+	assert.Equal(t, errFoo, (<-observe).E)
+	assert.Equal(t, []interface{}{1}, (<-observe).V)
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_BufferToggle_InputError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, Empty().BufferToggle(nil, func(interface{}) Observable { return Empty() }), HasAnError())
+	Assert(ctx, t, Empty().BufferToggle(Empty(), nil), HasAnError())
+}
+
+func Test_Observable_BufferUntil(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, -1, 3, 4, -1, 5).BufferUntil(func(i interface{}) bool {
+		return i.(int) < 0
+	})
+	Assert(ctx, t, obs, HasItems([]interface{}{1, 2, -1}, []interface{}{3, 4, -1}, []interface{}{5}))
+}
+
+func Test_Observable_BufferUntil_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, -1, 3, errFoo, 4).BufferUntil(func(i interface{}) bool {
+		return i.(int) < 0
+	})
+	Assert(ctx, t, obs, HasItems([]interface{}{1, 2, -1}, []interface{}{3}), HasError(errFoo))
+}
+
+func Test_Observable_BufferWhen(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	source := make(chan Item)
+	boundary := make(chan Item)
+	obs := FromChannel(source).BufferWhen(FromChannel(boundary))
+	go func() {
+		source <- Of(1)
+		source <- Of(2)
+		boundary <- Of(struct{}{})
+		source <- Of(3)
+		boundary <- Of(struct{}{})
+		// An empty buffer is not emitted
+		boundary <- Of(struct{}{})
+		source <- Of(4)
+		close(source)
+	}()
+	Assert(ctx, t, obs, HasItems([]interface{}{1, 2}, []interface{}{3}, []interface{}{4}))
+}
+
+func Test_Observable_BufferWhen_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, errFoo, 3).BufferWhen(Never())
+	Assert(ctx, t, obs, HasItems([]interface{}{1, 2}), HasError(errFoo))
+}
+
+func Test_Observable_BufferWhen_BoundaryError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	source := make(chan Item)
+	obs := FromChannel(source).BufferWhen(Thrown(errFoo))
+	go func() {
+		for i := 0; i < 3; i++ {
+			if !Of(i).SendContext(ctx, source) {
+				return
+			}
+		}
+		close(source)
+	}()
+	Assert(ctx, t, obs, HasError(errFoo))
+}
+
 func Test_Observable_BufferWithCount(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -271,6 +476,44 @@ func Test_Observable_BufferWithCount_InputError(t *testing.T) {
 	Assert(ctx, t, obs, HasAnError())
 }
 
+func Test_Observable_BufferWithCountSkip_Overlapping(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, 3, 4, 5).BufferWithCountSkip(3, 1)
+	Assert(ctx, t, obs, HasItems(
+		[]interface{}{1, 2, 3},
+		[]interface{}{2, 3, 4},
+		[]interface{}{3, 4, 5},
+		[]interface{}{4, 5},
+		[]interface{}{5},
+	))
+}
+
+func Test_Observable_BufferWithCountSkip_Skipping(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, 3, 4, 5, 6, 7).BufferWithCountSkip(2, 3)
+	Assert(ctx, t, obs, HasItems([]interface{}{1, 2}, []interface{}{4, 5}, []interface{}{7}))
+}
+
+func Test_Observable_BufferWithCountSkip_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, 3, errFoo).BufferWithCountSkip(2, 1)
+	Assert(ctx, t, obs, HasItems([]interface{}{1, 2}, []interface{}{2, 3}, []interface{}{3}), HasError(errFoo))
+}
+
+func Test_Observable_BufferWithCountSkip_InputError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, testObservable(ctx, 1, 2).BufferWithCountSkip(0, 1), HasAnError())
+	Assert(ctx, t, testObservable(ctx, 1, 2).BufferWithCountSkip(1, 0), HasAnError())
+}
+
 func Test_Observable_BufferWithTime_Single(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -323,6 +566,44 @@ func Test_Observable_BufferWithTimeOrCount(t *testing.T) {
 	}))
 }
 
+func Test_Observable_BufferWithTimeShift(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan Item, 1)
+	obs := FromChannel(ch).BufferWithTimeShift(WithDuration(100*time.Millisecond), WithDuration(50*time.Millisecond))
+	go func() {
+		ch <- Of(1)
+		time.Sleep(75 * time.Millisecond)
+		ch <- Of(2)
+		close(ch)
+	}()
+	Assert(ctx, t, obs, HasItems([]interface{}{1, 2}, []interface{}{2}))
+}
+
+func Test_Observable_BufferWithTimeShift_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, errFoo, 3).
+		BufferWithTimeShift(WithDuration(100*time.Millisecond), WithDuration(50*time.Millisecond))
+	Assert(ctx, t, obs, HasItems([]interface{}{1, 2}), HasError(errFoo))
+}
+
+func Test_Observable_BufferWithTimeShift_InputError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, Empty().BufferWithTimeShift(nil, WithDuration(time.Millisecond)), HasAnError())
+	Assert(ctx, t, Empty().BufferWithTimeShift(WithDuration(time.Millisecond), nil), HasAnError())
+	Assert(ctx, t, Empty().BufferWithTimeShift(WithDuration(0), WithDuration(time.Millisecond)),
+		HasError(IllegalInputError{error: "timespan must be positive"}))
+	Assert(ctx, t, Empty().BufferWithTimeShift(WithDuration(time.Millisecond), WithDuration(0)),
+		HasError(IllegalInputError{error: "timeshift must be positive"}))
+	Assert(ctx, t, Empty().BufferWithTimeShift(WithDuration(time.Millisecond), WithDuration(-time.Millisecond)),
+		HasError(IllegalInputError{error: "timeshift must be positive"}))
+}
+
 func Test_Observable_Contain(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -381,6 +662,50 @@ func Test_Observable_Count_Parallel(t *testing.T) {
 		HasItem(int64(10000)))
 }
 
+func Test_Observable_CountByKey(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "a", "b", "a", "c", "a", "b").CountByKey(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	})
+	Assert(ctx, t, obs, HasItem(map[interface{}]int{"a": 3, "b": 2, "c": 1}))
+}
+
+func Test_Observable_CountByKey_Parallel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := Range(0, 1000).CountByKey(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) % 3, nil
+	}, WithCPUPool())
+	Assert(ctx, t, obs, HasItem(map[interface{}]int{0: 334, 1: 333, 2: 333}))
+}
+
+func Test_Observable_CountByKey_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "a", "b").CountByKey(func(_ context.Context, i interface{}) (interface{}, error) {
+		if i == "b" {
+			return nil, errFoo
+		}
+		return i, nil
+	})
+	Assert(ctx, t, obs, HasError(errFoo))
+}
+
+func Test_Observable_CountByKeyWithTime(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "a", "b", "a").CountByKeyWithTime(WithDuration(time.Minute),
+		func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		})
+	Assert(ctx, t, obs, HasItem(map[interface{}]int{"a": 2, "b": 1}))
+}
+
 func Test_Observable_Debounce(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, obs, d := timeCausality(1, tick, 2, tick, 3, 4, 5, tick, 6, tick)
@@ -497,6 +822,88 @@ func Test_Observable_Distinct_Parallel_Error2(t *testing.T) {
 	Assert(ctx, t, obs, HasError(errFoo))
 }
 
+func Test_Observable_Distinct_Parallel_SharedState(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	items, err := Range(0, 1000).Distinct(func(_ context.Context, item interface{}) (interface{}, error) {
+		return item.(int) % 10, nil
+	}, WithContext(ctx), WithPool(8)).ToSlice(0)
+	assert.NoError(t, err)
+	assert.Len(t, items, 10)
+}
+
+func Test_Observable_Distinct_TTL(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan Item)
+	go func() {
+		defer close(ch)
+		Of(1).SendContext(ctx, ch)
+		Of(1).SendContext(ctx, ch)
+		time.Sleep(50 * time.Millisecond)
+		Of(1).SendContext(ctx, ch)
+	}()
+	obs := FromChannel(ch).Distinct(func(_ context.Context, item interface{}) (interface{}, error) {
+		return item, nil
+	}, WithDistinctTTL(WithDuration(20*time.Millisecond)))
+	Assert(ctx, t, obs, HasItems(1, 1), HasNoError())
+}
+
+func Test_Observable_Distinct_LRU(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, 1, 3, 2, 1).Distinct(func(_ context.Context, item interface{}) (interface{}, error) {
+		return item, nil
+	}, WithDistinctLRU(2))
+	Assert(ctx, t, obs, HasItems(1, 2, 3, 2, 1), HasNoError())
+}
+
+func Test_Observable_Distinct_LRU_Parallel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	items, err := Range(0, 1000).Distinct(func(_ context.Context, item interface{}) (interface{}, error) {
+		return item.(int) % 10, nil
+	}, WithContext(ctx), WithPool(8), WithDistinctLRU(10)).ToSlice(0)
+	assert.NoError(t, err)
+	assert.Len(t, items, 10)
+}
+
+func Test_Observable_Distinct_BloomFilter(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, 2, 1, 3).Distinct(func(_ context.Context, item interface{}) (interface{}, error) {
+		return item, nil
+	}, WithDistinctBloomFilter(100, 0.001))
+	Assert(ctx, t, obs, HasItems(1, 2, 3), HasNoError())
+}
+
+func Test_Observable_Distinct_InvalidKeySet(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	identityKey := func(_ context.Context, item interface{}) (interface{}, error) {
+		return item, nil
+	}
+
+	Assert(ctx, t, testObservable(ctx, 1, 2).Distinct(identityKey, WithDistinctLRU(0)),
+		IsEmpty(), HasError(IllegalInputError{error: "distinct LRU capacity must be positive"}))
+	Assert(ctx, t, testObservable(ctx, 1, 2).Distinct(identityKey, WithDistinctBloomFilter(0, 0.01)),
+		IsEmpty(), HasError(IllegalInputError{error: "distinct Bloom filter expected items must be positive"}))
+	for _, ttl := range []Duration{nil, WithDuration(0), WithDuration(-time.Second)} {
+		Assert(ctx, t, testObservable(ctx, 1, 2).Distinct(identityKey, WithDistinctTTL(ttl)),
+			IsEmpty(), HasError(IllegalInputError{error: "distinct TTL must be positive"}))
+	}
+	for _, rate := range []float64{0, 1, -0.5, 2, math.NaN()} {
+		Assert(ctx, t, testObservable(ctx, 1, 2).Distinct(identityKey, WithDistinctBloomFilter(100, rate)),
+			IsEmpty(), HasError(IllegalInputError{error: "distinct Bloom filter false positive rate must be in (0, 1)"}))
+	}
+}
+
 func Test_Observable_DistinctUntilChanged(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -658,6 +1065,91 @@ func Test_Observable_Errors_MultipleErrorFromMap(t *testing.T) {
 	assert.Equal(t, 2, len(errs))
 }
 
+func eventTime(i interface{}) time.Time {
+	return time.Unix(int64(i.(int)), 0)
+}
+
+func timeWindow(start, end int64, items ...interface{}) TimeWindow {
+	return TimeWindow{Start: time.Unix(start, 0), End: time.Unix(end, 0), Items: items}
+}
+
+func Test_Observable_EventTimeWindow_Tumbling(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	late := make(chan Item, 10)
+	obs := testObservable(ctx, 1, 4, 12, 3, 16, 2, 25).EventTimeWindow(eventTime,
+		WithDuration(10*time.Second), nil,
+		WithMaxOutOfOrderness(WithDuration(5*time.Second)), WithLateItems(late))
+	Assert(ctx, t, obs, HasItems(
+		// 3 is out of order but within the bound
+		timeWindow(0, 10, 1, 4, 3),
+		timeWindow(10, 20, 12, 16),
+		// The pending window is emitted on completion
+		timeWindow(20, 30, 25),
+	))
+	// 2 arrives once the watermark (16-5) passed the end of its window
+	assert.Equal(t, 1, len(late))
+	assert.Equal(t, 2, (<-late).V)
+}
+
+func Test_Observable_EventTimeWindow_Sliding(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 6, 12).EventTimeWindow(eventTime,
+		WithDuration(10*time.Second), WithDuration(5*time.Second))
+	Assert(ctx, t, obs, HasItems(
+		timeWindow(-5, 5, 1),
+		timeWindow(0, 10, 1, 6),
+		timeWindow(5, 15, 6, 12),
+		timeWindow(10, 20, 12),
+	))
+}
+
+func Test_Observable_EventTimeWindow_Gap(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	late := make(chan Item, 10)
+	obs := testObservable(ctx, 1, 7, 12).EventTimeWindow(eventTime,
+		WithDuration(5*time.Second), WithDuration(10*time.Second), WithLateItems(late))
+	Assert(ctx, t, obs, HasItems(
+		timeWindow(0, 5, 1),
+		timeWindow(10, 15, 12),
+	))
+	// 7 falls between [0, 5) and [10, 15): it is ignored, not late
+	assert.Equal(t, 0, len(late))
+}
+
+func Test_Observable_EventTimeWindow_LateDropped(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 12, 2, 13).EventTimeWindow(eventTime, WithDuration(10*time.Second), nil)
+	Assert(ctx, t, obs, HasItems(
+		timeWindow(0, 10, 1),
+		timeWindow(10, 20, 12, 13),
+	))
+}
+
+func Test_Observable_EventTimeWindow_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, errFoo, 3).EventTimeWindow(eventTime, WithDuration(10*time.Second), nil)
+	Assert(ctx, t, obs, HasItems(timeWindow(0, 10, 1, 2)), HasError(errFoo))
+}
+
+func Test_Observable_EventTimeWindow_InputError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, Empty().EventTimeWindow(nil, WithDuration(time.Second), nil), HasAnError())
+	Assert(ctx, t, Empty().EventTimeWindow(eventTime, nil, nil), HasAnError())
+	Assert(ctx, t, Empty().EventTimeWindow(eventTime, WithDuration(time.Second), WithDuration(0)), HasAnError())
+}
+
 func Test_Observable_Filter(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -879,11 +1371,41 @@ func Test_Observable_ForEach_Done(t *testing.T) {
 	assert.Nil(t, gotErr)
 }
 
-func Test_Observable_IgnoreElements(t *testing.T) {
+func Test_Observable_Histogram(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	obs := testObservable(ctx, 1, 2, 3).IgnoreElements()
+	obs := testObservable(ctx, 1, int8(5), 10.0, uint(11), 100, -3).Histogram([]float64{0, 10, 50})
+	Assert(ctx, t, obs, HasItem(HistogramCounts{
+		UpperBounds: []float64{0, 10, 50},
+		Counts:      []int{1, 3, 1, 1},
+	}))
+}
+
+func Test_Observable_Histogram_Parallel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := Range(0, 100).Histogram([]float64{49}, WithCPUPool())
+	Assert(ctx, t, obs, HasItem(HistogramCounts{
+		UpperBounds: []float64{49},
+		Counts:      []int{50, 50},
+	}))
+}
+
+func Test_Observable_Histogram_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, "foo").Histogram([]float64{0})
+	Assert(ctx, t, obs, HasAnError())
+}
+
+func Test_Observable_IgnoreElements(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, 3).IgnoreElements()
 	Assert(ctx, t, obs, IsEmpty())
 }
 
@@ -988,6 +1510,116 @@ func Test_Observable_GroupByDynamic(t *testing.T) {
 	assert.Equal(t, "10", s[3].(GroupedObservable).Key)
 }
 
+func groupKey(item Item) string {
+	return item.V.(string)[:1]
+}
+
+func assertGroups(ctx context.Context, t *testing.T, obs Observable, expected ...[]interface{}) {
+	t.Helper()
+	s, err := obs.ToSlice(0)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	if len(s) != len(expected) {
+		assert.FailNow(t, "length", "got=%d, expected=%d", len(s), len(expected))
+	}
+	for i, items := range expected {
+		group := s[i].(GroupedObservable)
+		assert.Equal(t, items[0].(string)[:1], group.Key)
+		Assert(ctx, t, group, HasItems(items...), HasNoError())
+	}
+}
+
+func Test_Observable_GroupByDynamic_IdleTimeout(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan Item)
+	obs := FromChannel(ch).GroupByDynamic(groupKey,
+		WithGroupIdleTimeout(WithDuration(30*time.Millisecond)), WithGroupBuffer(10, Block))
+	go func() {
+		ch <- Of("a1")
+		ch <- Of("b1")
+		time.Sleep(100 * time.Millisecond)
+		// The key returns once its group was completed
+		ch <- Of("a2")
+		close(ch)
+	}()
+	assertGroups(ctx, t, obs, []interface{}{"a1"}, []interface{}{"b1"}, []interface{}{"a2"})
+}
+
+func Test_Observable_GroupByDynamic_MaxGroupsLeastRecentlyUsed(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "a1", "b1", "a2", "c1", "b2").GroupByDynamic(groupKey,
+		WithMaxGroups(2, EvictLeastRecentlyUsed), WithGroupBuffer(10, Block))
+	assertGroups(ctx, t, obs,
+		[]interface{}{"a1", "a2"},
+		[]interface{}{"b1"},
+		[]interface{}{"c1"},
+		[]interface{}{"b2"},
+	)
+}
+
+func Test_Observable_GroupByDynamic_MaxGroupsOldest(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "a1", "b1", "a2", "c1", "b2").GroupByDynamic(groupKey,
+		WithMaxGroups(2, EvictOldest), WithGroupBuffer(10, Block))
+	assertGroups(ctx, t, obs,
+		[]interface{}{"a1", "a2"},
+		[]interface{}{"b1", "b2"},
+		[]interface{}{"c1"},
+	)
+}
+
+func Test_Observable_GroupByDynamic_GroupBufferDrop(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// The groups are not consumed before the source completes
+	obs := testObservable(ctx, "a1", "a2", "b1", "a3", "b2").GroupByDynamic(groupKey,
+		WithGroupBuffer(1, Drop))
+	assertGroups(ctx, t, obs, []interface{}{"a1"}, []interface{}{"b1"})
+}
+
+func Test_Observable_GroupByDynamic_GroupBufferLatest(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var dropped int32
+	obs := testObservable(ctx, "a1", "a2", "b1", "a3", "b2").GroupByDynamic(groupKey,
+		WithGroupBuffer(1, Latest), WithOnDrop(func(Item) {
+			atomic.AddInt32(&dropped, 1)
+		}))
+	assertGroups(ctx, t, obs, []interface{}{"a3"}, []interface{}{"b2"})
+	assert.Equal(t, int32(3), atomic.LoadInt32(&dropped))
+}
+
+func Test_Observable_GroupByDynamic_GroupBufferDropOldest(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "a1", "a2", "b1", "a3", "b2").GroupByDynamic(groupKey,
+		WithGroupBuffer(2, DropOldest))
+	assertGroups(ctx, t, obs, []interface{}{"a2", "a3"}, []interface{}{"b1", "b2"})
+}
+
+func Test_Observable_GroupByDynamic_GroupBufferThenError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := testObservable(ctx, "a1", "a2", "b1", "a3").GroupByDynamic(groupKey,
+		WithGroupBuffer(1, BufferThenError)).ToSlice(0)
+	assert.NoError(t, err)
+	assert.Len(t, s, 2)
+	Assert(ctx, t, s[0].(GroupedObservable), HasItems("a1"),
+		HasError(BufferOverflowError{error: "capacity of 1 items exceeded"}))
+	Assert(ctx, t, s[1].(GroupedObservable), HasItems("b1"), HasNoError())
+}
+
 func joinTest(ctx context.Context, t *testing.T, left, right []interface{}, window Duration, expected []int64) {
 	leftObs := testObservable(ctx, left...)
 	rightObs := testObservable(ctx, right...)
@@ -1145,6 +1777,96 @@ func Test_Observable_Join_Error_OnRight(t *testing.T) {
 	joinTest(ctx, t, left, right, window, expected)
 }
 
+func joinByKeyTest(ctx context.Context, t *testing.T, joinType JoinType, expected ...interface{}) {
+	t.Helper()
+	orders := testObservable(ctx, "A/order", "B/order", "C/order")
+	payments := testObservable(ctx, "A/payment", "B/payment1", "B/payment2", "D/payment")
+	key := func(i interface{}) interface{} {
+		return i.(string)[:1]
+	}
+	obs := orders.JoinByKey(joinPair, payments, key, key, joinType, nil)
+	Assert(ctx, t, obs, CustomPredicate(func(items []interface{}) error {
+		assert.ElementsMatch(t, expected, items)
+		return nil
+	}), HasNoError())
+}
+
+func joinPair(_ context.Context, l, r interface{}) (interface{}, error) {
+	return fmt.Sprintf("%v|%v", l, r), nil
+}
+
+func Test_Observable_JoinByKey_Inner(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	joinByKeyTest(ctx, t, InnerJoin,
+		"A/order|A/payment", "B/order|B/payment1", "B/order|B/payment2")
+}
+
+func Test_Observable_JoinByKey_LeftOuter(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	joinByKeyTest(ctx, t, LeftOuterJoin,
+		"A/order|A/payment", "B/order|B/payment1", "B/order|B/payment2", "C/order|<nil>")
+}
+
+func Test_Observable_JoinByKey_FullOuter(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	joinByKeyTest(ctx, t, FullOuterJoin,
+		"A/order|A/payment", "B/order|B/payment1", "B/order|B/payment2", "C/order|<nil>", "<nil>|D/payment")
+}
+
+func Test_Observable_JoinByKey_Window(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	left := make(chan Item)
+	right := make(chan Item)
+	key := func(i interface{}) interface{} {
+		return i.(string)[:1]
+	}
+	obs := FromChannel(left).JoinByKey(joinPair, FromChannel(right), key, key, FullOuterJoin,
+		WithDuration(20*time.Millisecond))
+	go func() {
+		left <- Of("A/order")
+		right <- Of("B/payment")
+		// The order is no longer retained when its payment arrives
+		time.Sleep(60 * time.Millisecond)
+		right <- Of("A/payment")
+		left <- Of("B/order")
+		close(left)
+		close(right)
+	}()
+	Assert(ctx, t, obs, HasItems("A/order|<nil>", "<nil>|B/payment", "<nil>|A/payment", "B/order|<nil>"))
+}
+
+func Test_Observable_JoinByKey_JoinerError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	key := func(i interface{}) interface{} {
+		return i
+	}
+	obs := testObservable(ctx, 1).JoinByKey(func(_ context.Context, _, _ interface{}) (interface{}, error) {
+		return nil, errFoo
+	}, testObservable(ctx, 1), key, key, InnerJoin, nil, WithName("payments"))
+	Assert(ctx, t, obs, IsEmpty(), HasError(OperatorError{Name: "payments", Operator: "JoinByKey", Value: 1, Err: errFoo}))
+}
+
+func Test_Observable_JoinByKey_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	key := func(i interface{}) interface{} {
+		return i
+	}
+	obs := testObservable(ctx, errFoo).JoinByKey(joinPair, Never(), key, key, InnerJoin, nil)
+	Assert(ctx, t, obs, IsEmpty(), HasError(errFoo))
+}
+
 func Test_Observable_Last_NotEmpty(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -1407,6 +2129,45 @@ func Test_Observable_Min_Parallel(t *testing.T) {
 	Assert(ctx, t, obs, HasItem(0))
 }
 
+func Test_Observable_MinMax(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 3, -1.5, uint8(7), int64(2)).MinMax()
+	Assert(ctx, t, obs, HasItem(NumericRange{Min: -1.5, Max: 7}))
+}
+
+func Test_Observable_MinMax_Parallel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := Range(0, 10000).MinMax(WithCPUPool())
+	Assert(ctx, t, obs, HasItem(NumericRange{Min: 0, Max: 9999}))
+}
+
+func Test_Observable_MinMax_Empty(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, Empty().MinMax(), IsEmpty())
+}
+
+func Test_Observable_MinMaxWithTime(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan Item)
+	obs := FromChannel(ch).MinMaxWithTime(WithDuration(30 * time.Millisecond))
+	go func() {
+		ch <- Of(2)
+		ch <- Of(4)
+		time.Sleep(100 * time.Millisecond)
+		ch <- Of(5)
+		close(ch)
+	}()
+	Assert(ctx, t, obs, HasItems(NumericRange{Min: 2, Max: 4}, NumericRange{Min: 5, Max: 5}))
+}
+
 func Test_Observable_Observe(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -1447,6 +2208,53 @@ func Test_Observable_OnErrorReturnItem(t *testing.T) {
 	Assert(ctx, t, obs, HasItems(1, 2, "foo", 4, "foo", 6), HasNoError())
 }
 
+func assertPercentiles(t *testing.T, expected []float64, delta float64) RxAssert {
+	return CustomPredicate(func(items []interface{}) error {
+		if len(items) != 1 {
+			return errors.New("wrong number of items")
+		}
+		values := items[0].([]float64)
+		if len(values) != len(expected) {
+			return errors.New("wrong number of percentiles")
+		}
+		for i, v := range values {
+			assert.InDelta(t, expected[i], v, delta)
+		}
+		return nil
+	})
+}
+
+func Test_Observable_Percentiles(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 5, int32(1), 4.0, uint16(2), 3).Percentiles([]float64{0, 0.5, 1})
+	Assert(ctx, t, obs, assertPercentiles(t, []float64{1, 3, 5}, 0))
+}
+
+func Test_Observable_Percentiles_Estimation(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := Range(1, 100000).Percentiles([]float64{0.5, 0.9, 0.99})
+	Assert(ctx, t, obs, assertPercentiles(t, []float64{50000, 90000, 99000}, 500))
+}
+
+func Test_Observable_Percentiles_Parallel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := Range(1, 100000).Percentiles([]float64{0.5, 0.99}, WithCPUPool())
+	Assert(ctx, t, obs, assertPercentiles(t, []float64{50000, 99000}, 500))
+}
+
+func Test_Observable_Percentiles_Empty(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, Empty().Percentiles([]float64{0.5}), IsEmpty())
+}
+
 func Test_Observable_Reduce(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -1853,6 +2661,128 @@ func Test_Observable_Serialize_Error(t *testing.T) {
 	Assert(ctx, t, obs, HasItems(message{1}), HasError(errFoo))
 }
 
+func Test_Observable_SessionWindow(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, 2, 3, 4, 5).SessionWindow(func(item Item) string {
+		return strconv.Itoa(item.V.(int) % 2)
+	}, WithDuration(time.Minute), nil)
+	s, err := obs.ToSlice(0)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	if len(s) != 2 {
+		assert.FailNow(t, "length", "got=%d, expected=%d", len(s), 2)
+	}
+
+	// The open sessions are completed when the source Observable completes
+	Assert(ctx, t, s[0].(GroupedObservable), HasItems(1, 3, 5), HasNoError())
+	assert.Equal(t, "1", s[0].(GroupedObservable).Key)
+	Assert(ctx, t, s[1].(GroupedObservable), HasItems(2, 4), HasNoError())
+	assert.Equal(t, "0", s[1].(GroupedObservable).Key)
+}
+
+func Test_Observable_SessionWindow_Gap(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan Item)
+	observe := FromChannel(ch).SessionWindow(func(item Item) string {
+		return item.V.(string)[:1]
+	}, WithDuration(50*time.Millisecond), nil).Observe()
+	go func() {
+		ch <- Of("a1")
+		ch <- Of("b1")
+		time.Sleep(100 * time.Millisecond)
+		ch <- Of("a2")
+		close(ch)
+	}()
+
+	a1 := (<-observe).V.(GroupedObservable)
+	b1 := (<-observe).V.(GroupedObservable)
+	// The sessions are completed after the gap, before the source Observable completes
+	Assert(ctx, t, a1, HasItems("a1"))
+	Assert(ctx, t, b1, HasItems("b1"))
+	a2 := (<-observe).V.(GroupedObservable)
+	assert.Equal(t, "a", a2.Key)
+	Assert(ctx, t, a2, HasItems("a2"))
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_SessionWindow_MaxDuration(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan Item)
+	obs := FromChannel(ch).SessionWindow(func(item Item) string {
+		return "a"
+	}, WithDuration(time.Minute), WithDuration(50*time.Millisecond))
+	go func() {
+		ch <- Of(1)
+		ch <- Of(2)
+		time.Sleep(80 * time.Millisecond)
+		ch <- Of(3)
+		close(ch)
+	}()
+	s, err := obs.ToSlice(0)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	if len(s) != 2 {
+		assert.FailNow(t, "length", "got=%d, expected=%d", len(s), 2)
+	}
+	Assert(ctx, t, s[0].(GroupedObservable), HasItems(1, 2))
+	Assert(ctx, t, s[1].(GroupedObservable), HasItems(3))
+}
+
+func Test_Observable_SessionWindow_IdleSource(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan Item)
+	obs := FromChannel(ch).SessionWindow(func(item Item) string {
+		return "a"
+	}, WithDuration(50*time.Millisecond), WithDuration(50*time.Millisecond))
+	go func() {
+		// The source is idle for longer than the gap and the max duration before the first item
+		time.Sleep(100 * time.Millisecond)
+		ch <- Of(1)
+		ch <- Of(2)
+		close(ch)
+	}()
+	s, err := obs.ToSlice(0)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	if len(s) != 1 {
+		assert.FailNow(t, "length", "got=%d, expected=%d", len(s), 1)
+	}
+	Assert(ctx, t, s[0].(GroupedObservable), HasItems(1, 2))
+}
+
+func Test_Observable_SessionWindow_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	observe := testObservable(ctx, 1, errFoo, 2).SessionWindow(func(item Item) string {
+		return "a"
+	}, WithDuration(time.Minute), nil).Observe()
+	Assert(ctx, t, (<-observe).V.(GroupedObservable), HasItems(1))
+	assert.Equal(t, errFoo, (<-observe).E)
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_SessionWindow_InputError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, Empty().SessionWindow(nil, WithDuration(time.Second), nil), HasAnError())
+	Assert(ctx, t, Empty().SessionWindow(func(Item) string { return "" }, nil, nil), HasAnError())
+}
+
 func Test_Observable_Skip(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -1917,6 +2847,123 @@ func Test_Observable_SkipWhile_Parallel(t *testing.T) {
 	Assert(ctx, t, obs, HasItems(3, 4, 5), HasNoError())
 }
 
+type stringCodec struct{}
+
+func (stringCodec) Marshal(v interface{}) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, errFoo
+	}
+	return []byte(s), nil
+}
+
+func (stringCodec) Unmarshal(b []byte) (interface{}, error) {
+	return string(b), nil
+}
+
+// compareFirstByte compares two strings on their first byte only, to check the sort stability.
+func compareFirstByte(a, b interface{}) int {
+	return int(a.(string)[0]) - int(b.(string)[0])
+}
+
+func Test_Observable_Sort(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "c1", "a1", "b1", "a2", "c2", "a3").Sort(compareFirstByte)
+	Assert(ctx, t, obs, HasItems("a1", "a2", "a3", "b1", "c1", "c2"))
+}
+
+func Test_Observable_Sort_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "b", errFoo, "a").Sort(compareFirstByte)
+	Assert(ctx, t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_Sort_ContinueOnError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "b", errFoo, "a").Sort(compareFirstByte, WithErrorStrategy(ContinueOnError))
+	Assert(ctx, t, obs, HasItems("a", "b"), HasError(errFoo))
+}
+
+func Test_Observable_SortExternal(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir, err := ioutil.TempDir("", "rxgo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	obs := testObservable(ctx, "c1", "a1", "b1", "a2", "c2", "a3", "b2").
+		SortExternal(compareFirstByte, 2, dir, stringCodec{})
+	Assert(ctx, t, obs, HasItems("a1", "a2", "a3", "b1", "b2", "c1", "c2"))
+	// The runs are removed
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func Test_Observable_SortExternal_InMemory(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "c", "a", "b").SortExternal(compareFirstByte, 10, "", stringCodec{})
+	Assert(ctx, t, obs, HasItems("a", "b", "c"))
+}
+
+func Test_Observable_SortExternal_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir, err := ioutil.TempDir("", "rxgo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	obs := testObservable(ctx, "c", "a", "b", errFoo).SortExternal(compareFirstByte, 2, dir, stringCodec{})
+	Assert(ctx, t, obs, IsEmpty(), HasError(errFoo))
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func Test_Observable_SortExternal_ContinueOnError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir, err := ioutil.TempDir("", "rxgo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	obs := testObservable(ctx, "c", "a", errFoo, "b").SortExternal(compareFirstByte, 2, dir, stringCodec{},
+		WithErrorStrategy(ContinueOnError))
+	Assert(ctx, t, obs, HasItems("a", "b", "c"), HasError(errFoo))
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func Test_Observable_SortExternal_CodecError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "a", 1).SortExternal(func(interface{}, interface{}) int {
+		return 0
+	}, 2, "", stringCodec{})
+	Assert(ctx, t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_SortExternal_InvalidInput(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, testObservable(ctx, "a").SortExternal(compareFirstByte, 0, "", stringCodec{}), HasAnError())
+	Assert(ctx, t, testObservable(ctx, "a").SortExternal(compareFirstByte, 1, "", nil), HasAnError())
+}
+
 func Test_Observable_StartWithIterable(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -1941,6 +2988,30 @@ func Test_Observable_StartWithIterable_Error2(t *testing.T) {
 	Assert(ctx, t, obs, HasItems(1, 2, 3, 4), HasError(errFoo))
 }
 
+func Test_Observable_StdDev(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 2, 4, 4, 4, 5, 5, 7, 9).StdDev()
+	Assert(ctx, t, obs, HasItem(2.))
+}
+
+func Test_Observable_StdDevWithTime(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan Item)
+	obs := FromChannel(ch).StdDevWithTime(WithDuration(30 * time.Millisecond))
+	go func() {
+		ch <- Of(2)
+		ch <- Of(4)
+		time.Sleep(100 * time.Millisecond)
+		ch <- Of(5)
+		close(ch)
+	}()
+	Assert(ctx, t, obs, HasItems(1., 0.))
+}
+
 func Test_Observable_SumFloat32_OnlyFloat32(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -2213,6 +3284,112 @@ func Test_Observable_ToSlice_Error(t *testing.T) {
 	assert.Equal(t, errFoo, err)
 }
 
+func Test_Observable_TopK(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "a", "b", "c", "b", "c", "d", "c", "d").TopK(2, func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	})
+	Assert(ctx, t, obs, HasItem([]KeyCount{{Key: "c", Count: 3}, {Key: "b", Count: 2}}))
+}
+
+func Test_Observable_TopK_LessKeys(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "a", "b", "b").TopK(5, func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	})
+	Assert(ctx, t, obs, HasItem([]KeyCount{{Key: "b", Count: 2}, {Key: "a", Count: 1}}))
+}
+
+func Test_Observable_TopK_Parallel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := Range(0, 1000).TopK(2, func(_ context.Context, i interface{}) (interface{}, error) {
+		if i.(int) < 10 {
+			return "rare", nil
+		}
+		return i.(int) % 2, nil
+	}, WithCPUPool())
+	Assert(ctx, t, obs, CustomPredicate(func(items []interface{}) error {
+		result := items[0].([]KeyCount)
+		assert.Len(t, result, 2)
+		for _, kc := range result {
+			assert.Equal(t, 495, kc.Count)
+		}
+		return nil
+	}))
+}
+
+func Test_Observable_TopK_Ties(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "c", "b", "a", "b", "a", "c").TopK(2, func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	})
+	Assert(ctx, t, obs, HasItem([]KeyCount{{Key: "a", Count: 2}, {Key: "b", Count: 2}}))
+}
+
+func Test_Observable_TopK_Ties_Parallel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Every key has the same count, the result must not depend on the worker scheduling
+	for i := 0; i < 10; i++ {
+		obs := Range(0, 1000).TopK(3, func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) % 10, nil
+		}, WithPool(8))
+		Assert(ctx, t, obs, HasItem([]KeyCount{{Key: 0, Count: 100}, {Key: 1, Count: 100}, {Key: 2, Count: 100}}))
+	}
+}
+
+func Test_Observable_TopK_SpaceSaving(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// A heavy hitter among many distinct keys
+	items := make([]interface{}, 0)
+	for i := 0; i < 1000; i++ {
+		items = append(items, "hot", i)
+	}
+	obs := testObservable(ctx, items...).TopK(1, func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, WithTopKSpaceSaving(10))
+	Assert(ctx, t, obs, CustomPredicate(func(items []interface{}) error {
+		result := items[0].([]KeyCount)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "hot", result[0].Key)
+		assert.GreaterOrEqual(t, result[0].Count, 1000)
+		assert.LessOrEqual(t, result[0].Count-result[0].Error, 1000)
+		return nil
+	}))
+}
+
+func Test_Observable_TopK_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "a", errFoo).TopK(1, func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	})
+	Assert(ctx, t, obs, HasError(errFoo))
+}
+
+func Test_Observable_TopKWithTime(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, "a", "b", "a").TopKWithTime(WithDuration(time.Minute), 1,
+		func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithTopKSpaceSaving(10))
+	Assert(ctx, t, obs, HasItem([]KeyCount{{Key: "a", Count: 2}}))
+}
+
 func Test_Observable_Unmarshal(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -2265,6 +3442,83 @@ func Test_Observable_Unmarshal_Parallel_Error(t *testing.T) {
 	Assert(ctx, t, obs, HasAnError())
 }
 
+func Test_Observable_Variance(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, int8(2), uint(4), float32(4), int64(4), 5, 5.0, uint32(7), 9).Variance()
+	Assert(ctx, t, obs, HasItem(4.))
+}
+
+func Test_Observable_Variance_Parallel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// The variance of 0..n-1 is (n²-1)/12
+	obs := Range(0, 1000).Variance(WithCPUPool())
+	Assert(ctx, t, obs, CustomPredicate(func(items []interface{}) error {
+		assert.InDelta(t, (1000.*1000.-1)/12, items[0], 1e-6)
+		return nil
+	}))
+}
+
+func Test_Observable_Variance_Empty(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, Empty().Variance(), HasItem(0.))
+}
+
+func Test_Observable_Variance_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, testObservable(ctx, 1, "foo").Variance(), HasAnError())
+}
+
+func Test_Observable_VarianceWithTime_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := testObservable(ctx, 1, "foo").VarianceWithTime(WithDuration(time.Minute))
+	Assert(ctx, t, obs, HasAnError())
+}
+
+func Test_Observable_WindowWhen(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	source := make(chan Item)
+	boundary := make(chan Item)
+	observe := FromChannel(source).WindowWhen(FromChannel(boundary)).Observe()
+	go func() {
+		source <- Of(1)
+		source <- Of(2)
+		boundary <- Of(struct{}{})
+		source <- Of(3)
+		boundary <- Of(struct{}{})
+		// An empty window is not emitted
+		boundary <- Of(struct{}{})
+		source <- Of(4)
+		close(source)
+	}()
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(1, 2))
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(3))
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(4))
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_WindowWhen_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	observe := testObservable(ctx, 1, 2, errFoo, 3).WindowWhen(Never()).Observe()
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(1, 2), HasError(errFoo))
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
 func Test_Observable_WindowWithCount(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -2300,6 +3554,65 @@ func Test_Observable_WindowWithCount_InputError(t *testing.T) {
 	Assert(ctx, t, obs, HasAnError())
 }
 
+func Test_Observable_WindowWithCountSkip(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	observe := testObservable(ctx, 1, 2, 3, 4, 5).WindowWithCountSkip(3, 2).Observe()
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(1, 2, 3))
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(3, 4, 5))
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(5))
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_WindowWithCountSkip_Skipping(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	observe := testObservable(ctx, 1, 2, 3, 4, 5).WindowWithCountSkip(1, 2).Observe()
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(1))
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(3))
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(5))
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_WindowWithCountSkip_ObservableError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	observe := testObservable(ctx, 1, 2, errFoo, 4).WindowWithCountSkip(2, 1).Observe()
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(1, 2))
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(2), HasError(errFoo))
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_WindowWithCountSkip_ContinueOnError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// The windows are consumed once the source completes, the errors must not fill them
+	s, err := testObservable(ctx, 1, errFoo, errFoo, 2, 3).
+		WindowWithCountSkip(2, 1, WithErrorStrategy(ContinueOnError)).ToSlice(0)
+	assert.NoError(t, err)
+	if len(s) != 3 {
+		assert.FailNow(t, "length", "got=%d, expected=%d", len(s), 3)
+	}
+	Assert(ctx, t, s[0].(Observable), HasItems(1, 2), HasErrors(errFoo, errFoo))
+	Assert(ctx, t, s[1].(Observable), HasItems(2, 3), HasNoError())
+	Assert(ctx, t, s[2].(Observable), HasItems(3), HasNoError())
+}
+
+func Test_Observable_WindowWithCountSkip_InputError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, Empty().WindowWithCountSkip(0, 1), HasAnError())
+	Assert(ctx, t, Empty().WindowWithCountSkip(1, -1), HasAnError())
+}
+
 // FIXME
 //func Test_Observable_WindowWithTime(t *testing.T) {
 //	defer goleak.VerifyNone(t)
@@ -2340,6 +3653,41 @@ func Test_Observable_WindowWithTimeOrCount(t *testing.T) {
 	Assert(ctx, t, (<-observe).V.(Observable), HasItems(3))
 }
 
+func Test_Observable_WindowWithTimeShift(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan Item, 1)
+	observe := FromChannel(ch).
+		WindowWithTimeShift(WithDuration(100*time.Millisecond), WithDuration(50*time.Millisecond)).
+		Observe()
+	go func() {
+		ch <- Of(1)
+		time.Sleep(75 * time.Millisecond)
+		ch <- Of(2)
+		close(ch)
+	}()
+	// Windows are consumed sequentially while they overlap
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(1, 2))
+	Assert(ctx, t, (<-observe).V.(Observable), HasItems(2))
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_WindowWithTimeShift_InputError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Assert(ctx, t, Empty().WindowWithTimeShift(nil, WithDuration(time.Millisecond)), HasAnError())
+	Assert(ctx, t, Empty().WindowWithTimeShift(WithDuration(time.Millisecond), nil), HasAnError())
+	Assert(ctx, t, Empty().WindowWithTimeShift(WithDuration(0), WithDuration(time.Millisecond)),
+		HasError(IllegalInputError{error: "timespan must be positive"}))
+	Assert(ctx, t, Empty().WindowWithTimeShift(WithDuration(time.Millisecond), WithDuration(0)),
+		HasError(IllegalInputError{error: "timeshift must be positive"}))
+	Assert(ctx, t, Empty().WindowWithTimeShift(WithDuration(time.Millisecond), WithDuration(-time.Millisecond)),
+		HasError(IllegalInputError{error: "timeshift must be positive"}))
+}
+
 func Test_Observable_ZipFromObservable(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	ctx, cancel := context.WithCancel(context.Background())
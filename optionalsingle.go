@@ -1,6 +1,10 @@
 package rxgo
 
-import "context"
+import (
+	"context"
+
+	"github.com/cenkalti/backoff/v4"
+)
 
 // OptionalSingleEmpty is the constant returned when an OptionalSingle is empty.
 var OptionalSingleEmpty = Item{}
@@ -8,9 +12,20 @@ var OptionalSingleEmpty = Item{}
 // OptionalSingle is an optional single.
 type OptionalSingle interface {
 	Iterable
+	BackOffRetry(backOffCfg backoff.BackOff, opts ...Option) OptionalSingle
+	Cache() OptionalSingle
+	DefaultIfEmpty(defaultValue interface{}, opts ...Option) Single
+	DoOnError(errFunc ErrFunc, opts ...Option) OptionalSingle
+	DoOnSuccess(nextFunc NextFunc, opts ...Option) OptionalSingle
+	FlatMap(apply ItemToSingle, opts ...Option) OptionalSingle
+	FlatMapObservable(apply ItemToObservable, opts ...Option) Observable
 	Get(opts ...Option) (Item, error)
 	Map(apply Func, opts ...Option) OptionalSingle
+	OnErrorResumeNext(resumeSequence ErrorToSingle, opts ...Option) OptionalSingle
+	OnErrorReturn(resumeFunc ErrorFunc, opts ...Option) OptionalSingle
+	Retry(count int, shouldRetry func(error) bool, opts ...Option) OptionalSingle
 	Run(opts ...Option) Disposed
+	ToObservable() Observable
 }
 
 // OptionalSingleImpl implements OptionalSingle.
@@ -19,6 +34,68 @@ type OptionalSingleImpl struct {
 	iterable Iterable
 }
 
+// BackOffRetry resubscribes to the OptionalSingle if it fails, following a backoff configuration.
+func (o *OptionalSingleImpl) BackOffRetry(backOffCfg backoff.BackOff, opts ...Option) OptionalSingle {
+	return &OptionalSingleImpl{
+		parent:   o.parent,
+		iterable: backOffRetryIterable(o.parent, o, backOffCfg, opts...),
+	}
+}
+
+// Cache subscribes to the OptionalSingle once, at the first observation, and replays its item to every observer.
+func (o *OptionalSingleImpl) Cache() OptionalSingle {
+	return &OptionalSingleImpl{
+		parent:   o.parent,
+		iterable: newCacheIterable(o),
+	}
+}
+
+// DefaultIfEmpty converts the OptionalSingle into a Single emitting a default value if the OptionalSingle is empty.
+func (o *OptionalSingleImpl) DefaultIfEmpty(defaultValue interface{}, opts ...Option) Single {
+	return single(o.parent, o, func() operator {
+		return &defaultIfEmptyOperator{
+			defaultValue: defaultValue,
+			empty:        true,
+		}
+	}, true, true, opts...)
+}
+
+// DoOnError calls a function if the OptionalSingle fails, and forwards the error.
+func (o *OptionalSingleImpl) DoOnError(errFunc ErrFunc, opts ...Option) OptionalSingle {
+	return optionalSingle(o.parent, o, func() operator {
+		return &doOnErrorOperator{errFunc: errFunc}
+	}, true, true, opts...)
+}
+
+// DoOnSuccess calls a function with the item of the OptionalSingle, if any, and forwards the item.
+func (o *OptionalSingleImpl) DoOnSuccess(nextFunc NextFunc, opts ...Option) OptionalSingle {
+	return optionalSingle(o.parent, o, func() operator {
+		return &doOnSuccessOperator{nextFunc: nextFunc}
+	}, true, true, opts...)
+}
+
+// FlatMap transforms the item of the OptionalSingle, if any, into a Single, and emits the item of this Single.
+// If the OptionalSingle fails, the error is forwarded without calling apply.
+func (o *OptionalSingleImpl) FlatMap(apply ItemToSingle, opts ...Option) OptionalSingle {
+	return &OptionalSingleImpl{
+		parent: o.parent,
+		iterable: flatMapIterable(o.parent, o, func(item Item) Iterable {
+			return apply(item)
+		}, opts...),
+	}
+}
+
+// FlatMapObservable transforms the item of the OptionalSingle, if any, into an Observable, and emits the items
+// of this Observable. If the OptionalSingle fails, the error is forwarded without calling apply.
+func (o *OptionalSingleImpl) FlatMapObservable(apply ItemToObservable, opts ...Option) Observable {
+	return &ObservableImpl{
+		parent: o.parent,
+		iterable: flatMapIterable(o.parent, o, func(item Item) Iterable {
+			return apply(item)
+		}, opts...),
+	}
+}
+
 // Get returns the item or rxgo.OptionalEmpty. The error returned is if the context has been cancelled.
 // This method is blocking.
 func (o *OptionalSingleImpl) Get(opts ...Option) (Item, error) {
@@ -58,7 +135,7 @@ type mapOperatorOptionalSingle struct {
 func (op *mapOperatorOptionalSingle) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	res, err := op.apply(ctx, item.V)
 	if err != nil {
-		dst <- Error(err)
+		dst <- Error(wrapOperatorError(operatorOptions.name, "Map", item.V, err))
 		operatorOptions.stop()
 		return
 	}
@@ -103,3 +180,36 @@ func (o *OptionalSingleImpl) Run(opts ...Option) Disposed {
 
 	return dispose
 }
+
+// OnErrorResumeNext instructs an OptionalSingle to pass control to a Single rather than failing.
+func (o *OptionalSingleImpl) OnErrorResumeNext(resumeSequence ErrorToSingle, opts ...Option) OptionalSingle {
+	return optionalSingle(o.parent, o, func() operator {
+		return &onErrorResumeNextOperator{resumeSequence: func(err error) Observable {
+			return resumeSequence(err).ToObservable()
+		}}
+	}, true, true, opts...)
+}
+
+// OnErrorReturn instructs an OptionalSingle to emit an item (returned by a specified function) rather than failing.
+func (o *OptionalSingleImpl) OnErrorReturn(resumeFunc ErrorFunc, opts ...Option) OptionalSingle {
+	return optionalSingle(o.parent, o, func() operator {
+		return &onErrorReturnOperator{resumeFunc: resumeFunc}
+	}, true, true, opts...)
+}
+
+// Retry resubscribes to the OptionalSingle if it fails, at most count times and as long as shouldRetry
+// returns true.
+func (o *OptionalSingleImpl) Retry(count int, shouldRetry func(error) bool, opts ...Option) OptionalSingle {
+	return &OptionalSingleImpl{
+		parent:   o.parent,
+		iterable: retryIterable(o.parent, o, count, shouldRetry, opts...),
+	}
+}
+
+// ToObservable converts the OptionalSingle into an Observable.
+func (o *OptionalSingleImpl) ToObservable() Observable {
+	return &ObservableImpl{
+		parent:   o.parent,
+		iterable: o,
+	}
+}
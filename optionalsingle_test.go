@@ -3,7 +3,9 @@ package rxgo
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/goleak"
 )
@@ -58,3 +60,112 @@ func Test_OptionalSingle_Observe(t *testing.T) {
 	})
 	Assert(context.Background(), t, os, HasItem(1), HasNoError())
 }
+
+func Test_OptionalSingle_BackOffRetry(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	os := completableCounter(&counter, errFoo).ToSingle(1).Filter(func(interface{}) bool {
+		return true
+	}).BackOffRetry(backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Millisecond), 1))
+	Assert(context.Background(), t, os, HasItem(1), HasNoError())
+	assert.Equal(t, 2, counter)
+}
+
+func Test_OptionalSingle_Cache(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	os := completableCounter(&counter).ToSingle(1).Filter(func(interface{}) bool {
+		return false
+	}).Cache()
+	Assert(context.Background(), t, os, IsEmpty(), HasNoError())
+	Assert(context.Background(), t, os, IsEmpty(), HasNoError())
+	assert.Equal(t, 1, counter)
+}
+
+func Test_OptionalSingle_DefaultIfEmpty(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	single := Empty().Max(func(_, _ interface{}) int {
+		return 0
+	}).DefaultIfEmpty(0)
+	Assert(context.Background(), t, single, HasItem(0), HasNoError())
+}
+
+func Test_OptionalSingle_DefaultIfEmpty_NotEmpty(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	single := JustItem(1).Filter(func(interface{}) bool {
+		return true
+	}).DefaultIfEmpty(0)
+	Assert(context.Background(), t, single, HasItem(1), HasNoError())
+}
+
+func Test_OptionalSingle_DoOnError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var got error
+	os := (&OptionalSingleImpl{iterable: Just(errFoo)()}).DoOnError(func(err error) {
+		got = err
+	})
+	Assert(context.Background(), t, os, HasError(errFoo))
+	assert.Equal(t, errFoo, got)
+}
+
+func Test_OptionalSingle_DoOnSuccess(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	called := false
+	os := (&OptionalSingleImpl{iterable: Empty()}).DoOnSuccess(func(interface{}) {
+		called = true
+	})
+	Assert(context.Background(), t, os, IsEmpty(), HasNoError())
+	assert.False(t, called)
+}
+
+func Test_OptionalSingle_FlatMap(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	os := JustItem(1).Filter(func(interface{}) bool {
+		return true
+	}).FlatMap(func(item Item) Single {
+		return JustItem(item.V.(int) + 1)
+	})
+	Assert(context.Background(), t, os, HasItem(2), HasNoError())
+}
+
+func Test_OptionalSingle_FlatMapObservable_Empty(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	obs := (&OptionalSingleImpl{iterable: Empty()}).FlatMapObservable(func(item Item) Observable {
+		return Just(1, 2)()
+	})
+	Assert(context.Background(), t, obs, IsEmpty(), HasNoError())
+}
+
+func Test_OptionalSingle_OnErrorResumeNext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	os := (&OptionalSingleImpl{iterable: Just(errFoo)()}).OnErrorResumeNext(func(error) Single {
+		return JustItem(1)
+	})
+	Assert(context.Background(), t, os, HasItem(1), HasNoError())
+}
+
+func Test_OptionalSingle_OnErrorReturn(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	os := (&OptionalSingleImpl{iterable: Just(errFoo)()}).OnErrorReturn(func(error) interface{} {
+		return 0
+	})
+	Assert(context.Background(), t, os, HasItem(0), HasNoError())
+}
+
+func Test_OptionalSingle_Retry(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	os := completableCounter(&counter, errFoo, errFoo).ToSingle(1).Filter(func(interface{}) bool {
+		return true
+	}).Retry(3, func(error) bool {
+		return true
+	})
+	Assert(context.Background(), t, os, HasItem(1), HasNoError())
+	assert.Equal(t, 3, counter)
+}
+
+func Test_OptionalSingle_ToObservable(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	obs := (&OptionalSingleImpl{iterable: Empty()}).ToObservable().DefaultIfEmpty(0)
+	Assert(context.Background(), t, obs, HasItems(0), HasNoError())
+}
@@ -3,6 +3,7 @@ package rxgo
 import (
 	"context"
 	"runtime"
+	"time"
 
 	"github.com/teivah/onecontext"
 )
@@ -15,13 +16,30 @@ type Option interface {
 	toPropagate() bool
 	isEagerObservation() bool
 	getPool() (bool, int)
+	isPoolOrdered() bool
+	getKeyedPool() (bool, func(interface{}) string)
+	buildKeySet() (keySet, error)
 	buildChannel() chan Item
 	buildContext(parent context.Context) context.Context
 	getBackPressureStrategy() BackpressureStrategy
+	getOnDrop() func(Item)
+	getSpillBuffer() *spillBuffer
+	isFusable() bool
+	getBatchTransport() *batchTransport
+	isBatchable() bool
 	getErrorStrategy() OnErrorStrategy
 	isConnectable() bool
 	isConnectOperation() bool
 	isSerialized() (bool, func(interface{}) int)
+	getName() string
+	getDeadLetter() chan<- Item
+	getMaxOutOfOrderness() time.Duration
+	getLateItems() chan<- Item
+	getGroupIdleTimeout() (bool, time.Duration)
+	getMaxGroups() (int, GroupEvictionPolicy)
+	buildGroupChannel() chan Item
+	getGroupBackPressureStrategy() BackpressureStrategy
+	getTopKSpaceSaving() int
 }
 
 type funcOption struct {
@@ -31,12 +49,30 @@ type funcOption struct {
 	ctx                  context.Context
 	observation          ObservationStrategy
 	pool                 int
+	orderedPool          bool
+	keyedPool            bool
+	keyFunc              func(interface{}) string
+	keySet               func() (keySet, error)
 	backPressureStrategy BackpressureStrategy
+	onDrop               func(Item)
+	spillBuffer          *spillBuffer
+	batchTransport       *batchTransport
 	onErrorStrategy      OnErrorStrategy
 	propagate            bool
 	connectable          bool
 	connectOperation     bool
 	serialized           func(interface{}) int
+	name                 string
+	deadLetter           chan<- Item
+	maxOutOfOrderness    Duration
+	lateItems            chan<- Item
+	groupIdleTimeout     Duration
+	maxGroups            int
+	groupEvictionPolicy  GroupEvictionPolicy
+	isGroupBuffer        bool
+	groupBuffer          int
+	groupBackPressure    BackpressureStrategy
+	topKCapacity         int
 }
 
 func (fdo *funcOption) toPropagate() bool {
@@ -51,6 +87,21 @@ func (fdo *funcOption) getPool() (bool, int) {
 	return fdo.pool > 0, fdo.pool
 }
 
+func (fdo *funcOption) isPoolOrdered() bool {
+	return fdo.orderedPool
+}
+
+func (fdo *funcOption) getKeyedPool() (bool, func(interface{}) string) {
+	return fdo.keyedPool, fdo.keyFunc
+}
+
+func (fdo *funcOption) buildKeySet() (keySet, error) {
+	if fdo.keySet == nil {
+		return newMapKeySet(), nil
+	}
+	return fdo.keySet()
+}
+
 func (fdo *funcOption) buildChannel() chan Item {
 	if fdo.isBuffer {
 		return make(chan Item, fdo.buffer)
@@ -77,6 +128,46 @@ func (fdo *funcOption) getBackPressureStrategy() BackpressureStrategy {
 	return fdo.backPressureStrategy
 }
 
+func (fdo *funcOption) getOnDrop() func(Item) {
+	return fdo.onDrop
+}
+
+func (fdo *funcOption) getSpillBuffer() *spillBuffer {
+	return fdo.spillBuffer
+}
+
+// isFusable returns whether an operator can run in the goroutine of the previous operator, meaning it does not
+// require its own channel, context or execution pool.
+func (fdo *funcOption) isFusable() bool {
+	return !fdo.isBuffer &&
+		fdo.ctx == nil &&
+		fdo.observation == Lazy &&
+		fdo.pool == 0 &&
+		!fdo.keyedPool &&
+		fdo.backPressureStrategy == Block &&
+		fdo.spillBuffer == nil &&
+		!fdo.propagate &&
+		!fdo.connectable &&
+		!fdo.connectOperation &&
+		fdo.serialized == nil
+}
+
+func (fdo *funcOption) getBatchTransport() *batchTransport {
+	return fdo.batchTransport
+}
+
+// isBatchable returns whether an operator can exchange batches of items with the previous and next operators.
+func (fdo *funcOption) isBatchable() bool {
+	return fdo.batchTransport != nil &&
+		fdo.observation == Lazy &&
+		fdo.pool == 0 &&
+		!fdo.keyedPool &&
+		fdo.backPressureStrategy == Block &&
+		fdo.spillBuffer == nil &&
+		!fdo.connectable &&
+		fdo.serialized == nil
+}
+
 func (fdo *funcOption) getErrorStrategy() OnErrorStrategy {
 	return fdo.onErrorStrategy
 }
@@ -100,6 +191,51 @@ func (fdo *funcOption) isSerialized() (bool, func(interface{}) int) {
 	return true, fdo.serialized
 }
 
+func (fdo *funcOption) getName() string {
+	return fdo.name
+}
+
+func (fdo *funcOption) getDeadLetter() chan<- Item {
+	return fdo.deadLetter
+}
+
+func (fdo *funcOption) getMaxOutOfOrderness() time.Duration {
+	if fdo.maxOutOfOrderness == nil {
+		return 0
+	}
+	return fdo.maxOutOfOrderness.duration()
+}
+
+func (fdo *funcOption) getLateItems() chan<- Item {
+	return fdo.lateItems
+}
+
+func (fdo *funcOption) getGroupIdleTimeout() (bool, time.Duration) {
+	if fdo.groupIdleTimeout == nil {
+		return false, 0
+	}
+	return true, fdo.groupIdleTimeout.duration()
+}
+
+func (fdo *funcOption) getMaxGroups() (int, GroupEvictionPolicy) {
+	return fdo.maxGroups, fdo.groupEvictionPolicy
+}
+
+func (fdo *funcOption) buildGroupChannel() chan Item {
+	if fdo.isGroupBuffer {
+		return make(chan Item, fdo.groupBuffer)
+	}
+	return fdo.buildChannel()
+}
+
+func (fdo *funcOption) getGroupBackPressureStrategy() BackpressureStrategy {
+	return fdo.groupBackPressure
+}
+
+func (fdo *funcOption) getTopKSpaceSaving() int {
+	return fdo.topKCapacity
+}
+
 func newFuncOption(f func(*funcOption)) *funcOption {
 	return &funcOption{
 		f: f,
@@ -143,6 +279,28 @@ func WithPool(pool int) Option {
 	})
 }
 
+// WithOrderedPool allows to specify an execution pool preserving the order of the source items.
+// The items are internally sequenced and re-ordered once processed.
+// The number of items in flight is bounded to twice the pool size.
+func WithOrderedPool(pool int) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.pool = pool
+		options.orderedPool = true
+	})
+}
+
+// WithKeyedPool allows to specify an execution pool where the items having the same key are always processed
+// by the same goroutine. Hence, the order is preserved per key while different keys are processed in parallel.
+// The pool must be positive and the key function must not be nil, otherwise the operator emits an
+// IllegalInputError.
+func WithKeyedPool(pool int, keyFunc func(interface{}) string) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.pool = pool
+		options.keyedPool = true
+		options.keyFunc = keyFunc
+	})
+}
+
 // WithCPUPool allows to specify an execution pool based on the number of logical CPUs.
 func WithCPUPool() Option {
 	return newFuncOption(func(options *funcOption) {
@@ -150,13 +308,61 @@ func WithCPUPool() Option {
 	})
 }
 
-// WithBackPressureStrategy sets the back pressure strategy: drop or block.
+// WithBackPressureStrategy sets the back pressure strategy applied to the output channel when the downstream is
+// not ready to consume an item. Except for Block and Drop, the items are buffered up to the capacity set using
+// WithBufferedChannel.
 func WithBackPressureStrategy(strategy BackpressureStrategy) Option {
 	return newFuncOption(func(options *funcOption) {
 		options.backPressureStrategy = strategy
 	})
 }
 
+// WithOnDrop sets a function called with each item dropped by the back pressure strategy.
+func WithOnDrop(onDrop func(Item)) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.onDrop = onDrop
+	})
+}
+
+// WithSpillBuffer spills the items to a segment file in a directory once the output channel buffer, whose
+// capacity is set using WithBufferedChannel, is full. The spilled items are encoded using a codec and emitted in
+// order as soon as the downstream is ready. If maxBytes is positive and the segment file would exceed it, a
+// BufferOverflowError is emitted after the pending items and the items received in the meantime are dropped;
+// the next items are spilled again. If the segment file cannot be written, the error is emitted after the
+// pending items and every next item is dropped.
+// Each observation spills to its own segment file, so a directory can be shared. If the observation is canceled,
+// the items not consumed yet are kept in the directory and emitted first by the next observation using it. Only
+// canceled observations are recovered: the segment file of a process that crashed is left in the directory but
+// its items are not emitted again.
+// A spilled error is restored as a new error having the same message: its type and wrapped errors are lost, so
+// errors.Is and errors.As do not match it.
+func WithSpillBuffer(dir string, maxBytes int64, codec Codec) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.spillBuffer = &spillBuffer{
+			dir:      dir,
+			maxBytes: maxBytes,
+			codec:    codec,
+		}
+	})
+}
+
+// WithBatchTransport makes a sequential Map, Filter or Reduce operator exchange the items in batches with the
+// previous and next operators also using a batch transport. The functions are still called with each item.
+// A batch is emitted once it contains size items or once the linger duration has elapsed since its first item.
+// A nil linger means a partial batch is emitted only once the operator completes.
+func WithBatchTransport(size int, linger Duration) Option {
+	return newFuncOption(func(options *funcOption) {
+		if size < 1 {
+			size = 1
+		}
+		transport := &batchTransport{size: size}
+		if linger != nil {
+			transport.linger = linger.duration()
+		}
+		options.batchTransport = transport
+	})
+}
+
 // WithErrorStrategy defines how an observable should deal with error.
 // This strategy is propagated to the parent observable.
 func WithErrorStrategy(strategy OnErrorStrategy) Option {
@@ -165,6 +371,51 @@ func WithErrorStrategy(strategy OnErrorStrategy) Option {
 	})
 }
 
+// WithDistinctTTL bounds the Distinct operator state by keeping a key for a given time to live.
+// An item is suppressed only if its key was first seen within this time window.
+// The time to live must be positive, otherwise Distinct emits an IllegalInputError.
+func WithDistinctTTL(ttl Duration) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.keySet = func() (keySet, error) {
+			if ttl == nil {
+				return nil, IllegalInputError{error: "distinct TTL must be positive"}
+			}
+			return newTTLKeySet(ttl.duration())
+		}
+	})
+}
+
+// WithDistinctLRU bounds the Distinct operator state to a maximum number of keys.
+// Once the capacity is reached, the least recently seen key is evicted.
+// The capacity must be positive, otherwise Distinct emits an IllegalInputError.
+func WithDistinctLRU(capacity int) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.keySet = func() (keySet, error) {
+			return newLRUKeySet(capacity)
+		}
+	})
+}
+
+// WithDistinctBloomFilter bounds the Distinct operator state using a Bloom filter sized for an expected number of
+// keys and a false positive rate. A duplicate is never emitted but a new item may be wrongly suppressed.
+// The expected number of keys must be positive and the false positive rate in (0, 1), otherwise Distinct emits an
+// IllegalInputError.
+func WithDistinctBloomFilter(expectedItems int, falsePositiveRate float64) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.keySet = func() (keySet, error) {
+			return newBloomKeySet(expectedItems, falsePositiveRate)
+		}
+	})
+}
+
+// WithTopKSpaceSaving makes the TopK operator approximate, using the Space-Saving algorithm with a bounded
+// number of counters instead of counting every key. The counts may be overestimated.
+func WithTopKSpaceSaving(capacity int) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.topKCapacity = capacity
+	})
+}
+
 // WithPublishStrategy converts an ordinary Observable into a connectable Observable.
 func WithPublishStrategy() Option {
 	return newFuncOption(func(options *funcOption) {
@@ -179,6 +430,70 @@ func Serialize(identifier func(interface{}) int) Option {
 	})
 }
 
+// WithName names an operator stage.
+// The errors raised by a named stage are wrapped into an OperatorError.
+func WithName(name string) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.name = name
+	})
+}
+
+// WithDeadLetter routes the inputs an operator failed to process, paired with their error, to a sink.
+// The output of the operator then contains only the successfully processed items.
+// A routed error does not stop the operator, whatever the error strategy: StopOnError applies only to the errors
+// emitted downstream, i.e. when no dead letter sink is set.
+// The sink is not closed once the operator completes.
+func WithDeadLetter(sink chan<- Item) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.deadLetter = sink
+	})
+}
+
+// WithMaxOutOfOrderness sets the bound on how late an item may arrive compared to the latest event time seen,
+// for the event-time operators. The watermark is the latest event time seen minus this bound.
+func WithMaxOutOfOrderness(bound Duration) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.maxOutOfOrderness = bound
+	})
+}
+
+// WithLateItems routes the items arriving after the watermark passed all their event-time windows to a sink.
+// Without this option, the late items are dropped.
+// The sink is not closed once the operator completes.
+func WithLateItems(sink chan<- Item) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.lateItems = sink
+	})
+}
+
+// WithGroupIdleTimeout completes a GroupByDynamic group once it has received no item during the timeout.
+// If the key returns, a new group is emitted.
+func WithGroupIdleTimeout(timeout Duration) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.groupIdleTimeout = timeout
+	})
+}
+
+// WithMaxGroups bounds the number of live GroupByDynamic groups.
+// Once the maximum is reached, a new key completes a group according to the eviction policy.
+func WithMaxGroups(max int, policy GroupEvictionPolicy) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.maxGroups = max
+		options.groupEvictionPolicy = policy
+	})
+}
+
+// WithGroupBuffer sets the channel capacity of each GroupByDynamic group and what to do when a group is full:
+// block the dispatching of every group, drop the item, keep the latest item only, drop the oldest item or emit a
+// BufferOverflowError in the group. The dropped items are passed to the WithOnDrop callback.
+func WithGroupBuffer(capacity int, strategy BackpressureStrategy) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.isGroupBuffer = true
+		options.groupBuffer = capacity
+		options.groupBackPressure = strategy
+	})
+}
+
 func connect() Option {
 	return newFuncOption(func(options *funcOption) {
 		options.connectOperation = true
@@ -2,15 +2,28 @@ package rxgo
 
 import (
 	"context"
+
+	"github.com/cenkalti/backoff/v4"
 )
 
 // Single is a observable with a single element.
 type Single interface {
 	Iterable
+	Await(ctx context.Context) (interface{}, error)
+	BackOffRetry(backOffCfg backoff.BackOff, opts ...Option) Single
+	Cache() Single
+	DoOnError(errFunc ErrFunc, opts ...Option) Single
+	DoOnSuccess(nextFunc NextFunc, opts ...Option) Single
 	Filter(apply Predicate, opts ...Option) OptionalSingle
+	FlatMap(apply ItemToSingle, opts ...Option) Single
+	FlatMapObservable(apply ItemToObservable, opts ...Option) Observable
 	Get(opts ...Option) (Item, error)
 	Map(apply Func, opts ...Option) Single
+	OnErrorResumeNext(resumeSequence ErrorToSingle, opts ...Option) Single
+	OnErrorReturn(resumeFunc ErrorFunc, opts ...Option) Single
+	Retry(count int, shouldRetry func(error) bool, opts ...Option) Single
 	Run(opts ...Option) Disposed
+	ToObservable() Observable
 }
 
 // SingleImpl implements Single.
@@ -19,6 +32,58 @@ type SingleImpl struct {
 	iterable Iterable
 }
 
+// Await subscribes to the Single and returns its value or its error.
+// If the context is canceled first, the subscription is canceled and the context error is returned.
+// This method is blocking.
+func (s *SingleImpl) Await(ctx context.Context) (interface{}, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	observe := s.Observe(WithContext(ctx))
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case item, ok := <-observe:
+		if !ok {
+			return nil, nil
+		}
+		if item.Error() {
+			return nil, item.E
+		}
+		return item.V, nil
+	}
+}
+
+// BackOffRetry resubscribes to the Single if it fails, following a backoff configuration.
+func (s *SingleImpl) BackOffRetry(backOffCfg backoff.BackOff, opts ...Option) Single {
+	return &SingleImpl{
+		parent:   s.parent,
+		iterable: backOffRetryIterable(s.parent, s, backOffCfg, opts...),
+	}
+}
+
+// Cache subscribes to the Single once, at the first observation, and replays its item to every observer.
+func (s *SingleImpl) Cache() Single {
+	return &SingleImpl{
+		parent:   s.parent,
+		iterable: newCacheIterable(s),
+	}
+}
+
+// DoOnError calls a function if the Single fails, and forwards the error.
+func (s *SingleImpl) DoOnError(errFunc ErrFunc, opts ...Option) Single {
+	return single(s.parent, s, func() operator {
+		return &doOnErrorOperator{errFunc: errFunc}
+	}, true, true, opts...)
+}
+
+// DoOnSuccess calls a function with the item of the Single, and forwards the item.
+func (s *SingleImpl) DoOnSuccess(nextFunc NextFunc, opts ...Option) Single {
+	return single(s.parent, s, func() operator {
+		return &doOnSuccessOperator{nextFunc: nextFunc}
+	}, true, true, opts...)
+}
+
 // Filter emits only those items from an Observable that pass a predicate test.
 func (s *SingleImpl) Filter(apply Predicate, opts ...Option) OptionalSingle {
 	return optionalSingle(s.parent, s, func() operator {
@@ -26,6 +91,28 @@ func (s *SingleImpl) Filter(apply Predicate, opts ...Option) OptionalSingle {
 	}, true, true, opts...)
 }
 
+// FlatMap transforms the item of the Single into a Single, and emits the item of this Single.
+// If the Single fails, the error is forwarded without calling apply.
+func (s *SingleImpl) FlatMap(apply ItemToSingle, opts ...Option) Single {
+	return &SingleImpl{
+		parent: s.parent,
+		iterable: flatMapIterable(s.parent, s, func(item Item) Iterable {
+			return apply(item)
+		}, opts...),
+	}
+}
+
+// FlatMapObservable transforms the item of the Single into an Observable, and emits the items of this Observable.
+// If the Single fails, the error is forwarded without calling apply.
+func (s *SingleImpl) FlatMapObservable(apply ItemToObservable, opts ...Option) Observable {
+	return &ObservableImpl{
+		parent: s.parent,
+		iterable: flatMapIterable(s.parent, s, func(item Item) Iterable {
+			return apply(item)
+		}, opts...),
+	}
+}
+
 // Get returns the item. The error returned is if the context has been cancelled.
 // This method is blocking.
 func (s *SingleImpl) Get(opts ...Option) (Item, error) {
@@ -57,7 +144,7 @@ type mapOperatorSingle struct {
 func (op *mapOperatorSingle) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	res, err := op.apply(ctx, item.V)
 	if err != nil {
-		Error(err).SendContext(ctx, dst)
+		Error(wrapOperatorError(operatorOptions.name, "Map", item.V, err)).SendContext(ctx, dst)
 		operatorOptions.stop()
 		return
 	}
@@ -84,6 +171,30 @@ func (s *SingleImpl) Observe(opts ...Option) <-chan Item {
 	return s.iterable.Observe(opts...)
 }
 
+// OnErrorResumeNext instructs a Single to pass control to another Single rather than failing.
+func (s *SingleImpl) OnErrorResumeNext(resumeSequence ErrorToSingle, opts ...Option) Single {
+	return single(s.parent, s, func() operator {
+		return &onErrorResumeNextOperator{resumeSequence: func(err error) Observable {
+			return resumeSequence(err).ToObservable()
+		}}
+	}, true, true, opts...)
+}
+
+// OnErrorReturn instructs a Single to emit an item (returned by a specified function) rather than failing.
+func (s *SingleImpl) OnErrorReturn(resumeFunc ErrorFunc, opts ...Option) Single {
+	return single(s.parent, s, func() operator {
+		return &onErrorReturnOperator{resumeFunc: resumeFunc}
+	}, true, true, opts...)
+}
+
+// Retry resubscribes to the Single if it fails, at most count times and as long as shouldRetry returns true.
+func (s *SingleImpl) Retry(count int, shouldRetry func(error) bool, opts ...Option) Single {
+	return &SingleImpl{
+		parent:   s.parent,
+		iterable: retryIterable(s.parent, s, count, shouldRetry, opts...),
+	}
+}
+
 type filterOperatorSingle struct {
 	apply Predicate
 }
@@ -127,3 +238,143 @@ func (s *SingleImpl) Run(opts ...Option) Disposed {
 
 	return dispose
 }
+
+// ToObservable converts the Single into an Observable.
+func (s *SingleImpl) ToObservable() Observable {
+	return &ObservableImpl{
+		parent:   s.parent,
+		iterable: s,
+	}
+}
+
+type doOnSuccessOperator struct {
+	nextFunc NextFunc
+}
+
+func (op *doOnSuccessOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
+	op.nextFunc(item.V)
+	item.SendContext(ctx, dst)
+}
+
+func (op *doOnSuccessOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *doOnSuccessOperator) end(_ context.Context, _ chan<- Item) {
+}
+
+func (op *doOnSuccessOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+type doOnErrorOperator struct {
+	errFunc ErrFunc
+}
+
+func (op *doOnErrorOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
+	item.SendContext(ctx, dst)
+}
+
+func (op *doOnErrorOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	op.errFunc(item.E)
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *doOnErrorOperator) end(_ context.Context, _ chan<- Item) {
+}
+
+func (op *doOnErrorOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+// forwardValues forwards the values of an observation until it completes and returns its first error,
+// which is not forwarded. If the context is canceled first, the context error is returned.
+func forwardValues(ctx context.Context, observe <-chan Item, next chan<- Item) error {
+	var err error
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case item, ok := <-observe:
+			if !ok {
+				return err
+			}
+			if item.Error() {
+				if err == nil {
+					err = item.E
+				}
+				continue
+			}
+			if err == nil {
+				item.SendContext(ctx, next)
+			}
+		}
+	}
+}
+
+func retryIterable(parent context.Context, iterable Iterable, count int, shouldRetry func(error) bool, opts ...Option) Iterable {
+	return newOperatorIterable(parent, func(ctx context.Context, next chan Item, opts ...Option) {
+		defer close(next)
+		// Each observation is retried count times
+		remaining := count
+		for {
+			err := forwardValues(ctx, iterable.Observe(opts...), next)
+			if err == nil || ctx.Err() != nil {
+				return
+			}
+			remaining--
+			if remaining < 0 || !shouldRetry(err) {
+				Error(err).SendContext(ctx, next)
+				return
+			}
+		}
+	}, opts...)
+}
+
+func backOffRetryIterable(parent context.Context, iterable Iterable, backOffCfg backoff.BackOff, opts ...Option) Iterable {
+	return newOperatorIterable(parent, func(ctx context.Context, next chan Item, opts ...Option) {
+		defer close(next)
+		err := backoff.Retry(func() error {
+			err := forwardValues(ctx, iterable.Observe(opts...), next)
+			if ctx.Err() != nil {
+				return backoff.Permanent(ctx.Err())
+			}
+			return err
+		}, backOffCfg)
+		if err != nil && ctx.Err() == nil {
+			Error(err).SendContext(ctx, next)
+		}
+	}, opts...)
+}
+
+func flatMapIterable(parent context.Context, iterable Iterable, apply func(Item) Iterable, opts ...Option) Iterable {
+	return newOperatorIterable(parent, func(ctx context.Context, next chan Item, opts ...Option) {
+		defer close(next)
+		observe := iterable.Observe(opts...)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				if item.Error() {
+					item.SendContext(ctx, next)
+					continue
+				}
+				observe2 := apply(item).Observe(opts...)
+			loop:
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case item2, ok := <-observe2:
+						if !ok {
+							break loop
+						}
+						item2.SendContext(ctx, next)
+					}
+				}
+			}
+		}
+	}, opts...)
+}
@@ -3,7 +3,9 @@ package rxgo
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/goleak"
 )
@@ -58,3 +60,154 @@ func Test_Single_Map(t *testing.T) {
 	})
 	Assert(context.Background(), t, single, HasItem(2), HasNoError())
 }
+
+func Test_Single_BackOffRetry(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	single := completableCounter(&counter, errFoo, errFoo).ToSingle(1).
+		BackOffRetry(backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Millisecond), 3))
+	Assert(context.Background(), t, single, HasItem(1), HasNoError())
+	assert.Equal(t, 3, counter)
+}
+
+func Test_Single_BackOffRetry_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	single := completableCounter(&counter, errFoo, errFoo, errFoo).ToSingle(1).
+		BackOffRetry(backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Millisecond), 1))
+	Assert(context.Background(), t, single, IsEmpty(), HasError(errFoo))
+	assert.Equal(t, 2, counter)
+}
+
+func Test_Single_Cache(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	single := completableCounter(&counter).ToSingle(1).Cache()
+	Assert(context.Background(), t, single, HasItem(1), HasNoError())
+	Assert(context.Background(), t, single, HasItem(1), HasNoError())
+	assert.Equal(t, 1, counter)
+}
+
+func Test_Single_DoOnError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var got error
+	single := JustItem(errFoo).DoOnError(func(err error) {
+		got = err
+	})
+	Assert(context.Background(), t, single, HasError(errFoo))
+	assert.Equal(t, errFoo, got)
+}
+
+func Test_Single_DoOnSuccess(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	var got interface{}
+	single := JustItem(1).DoOnSuccess(func(i interface{}) {
+		got = i
+	})
+	Assert(context.Background(), t, single, HasItem(1), HasNoError())
+	assert.Equal(t, 1, got)
+}
+
+func Test_Single_FlatMap(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	single := JustItem(1).FlatMap(func(item Item) Single {
+		return JustItem(item.V.(int) + 1)
+	})
+	Assert(context.Background(), t, single, HasItem(2), HasNoError())
+}
+
+func Test_Single_FlatMap_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	single := JustItem(errFoo).FlatMap(func(item Item) Single {
+		assert.FailNow(t, "apply must not be called")
+		return nil
+	})
+	Assert(context.Background(), t, single, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Single_FlatMapObservable(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	obs := JustItem(2).FlatMapObservable(func(item Item) Observable {
+		return Range(0, item.V.(int))
+	})
+	Assert(context.Background(), t, obs, HasItems(0, 1), HasNoError())
+}
+
+func Test_Single_OnErrorResumeNext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	single := JustItem(errFoo).OnErrorResumeNext(func(err error) Single {
+		return JustItem(err.Error())
+	})
+	Assert(context.Background(), t, single, HasItem(errFoo.Error()), HasNoError())
+}
+
+func Test_Single_OnErrorReturn(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	single := JustItem(errFoo).OnErrorReturn(func(error) interface{} {
+		return 0
+	})
+	Assert(context.Background(), t, single, HasItem(0), HasNoError())
+}
+
+func Test_Single_Retry(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	single := completableCounter(&counter, errFoo).ToSingle(1).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) + 1, nil
+	}).Retry(1, func(error) bool {
+		return true
+	})
+	Assert(context.Background(), t, single, HasItem(2), HasNoError())
+	assert.Equal(t, 2, counter)
+}
+
+func Test_Single_Retry_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	single := completableCounter(&counter, errFoo, errFoo).ToSingle(1).Retry(1, func(error) bool {
+		return true
+	})
+	Assert(context.Background(), t, single, IsEmpty(), HasError(errFoo))
+	assert.Equal(t, 2, counter)
+}
+
+func Test_Single_Retry_Resubscribe(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	counter := 0
+	single := FromAction(func(context.Context) error {
+		counter++
+		return errFoo
+	}).ToSingle(1).Retry(1, func(error) bool {
+		return true
+	})
+	// Each observation is retried the same number of times
+	Assert(context.Background(), t, single, IsEmpty(), HasError(errFoo))
+	assert.Equal(t, 2, counter)
+	Assert(context.Background(), t, single, IsEmpty(), HasError(errFoo))
+	assert.Equal(t, 4, counter)
+}
+
+func Test_Single_ToObservable(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	obs := JustItem(1).ToObservable().Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) + 1, nil
+	})
+	Assert(context.Background(), t, obs, HasItems(2), HasNoError())
+}
+
+func Test_Single_Await(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	v, err := JustItem(1).Await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	_, err = JustItem(errFoo).Await(context.Background())
+	assert.Equal(t, errFoo, err)
+}
+
+func Test_Single_Await_ContextCanceled(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := (&SingleImpl{iterable: Never()}).Await(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
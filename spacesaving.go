@@ -0,0 +1,103 @@
+package rxgo
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// spaceSaving implements the Space-Saving algorithm, finding the most frequent keys of a stream with a bounded
+// number of counters. Once the capacity is reached, a new key replaces the key having the lowest count and
+// inherits its count. Hence, a count is overestimated by at most the inherited count.
+type spaceSaving struct {
+	capacity int
+	counters map[interface{}]*spaceSavingCounter
+	// Min-heap of the counters by count
+	heap spaceSavingHeap
+}
+
+type spaceSavingCounter struct {
+	key   interface{}
+	count int
+	error int
+	index int
+}
+
+func newSpaceSaving(capacity int) *spaceSaving {
+	return &spaceSaving{
+		capacity: capacity,
+		counters: make(map[interface{}]*spaceSavingCounter),
+		heap:     make(spaceSavingHeap, 0, capacity),
+	}
+}
+
+func (s *spaceSaving) add(key interface{}) {
+	if c, exists := s.counters[key]; exists {
+		c.count++
+		heap.Fix(&s.heap, c.index)
+		return
+	}
+	if len(s.heap) < s.capacity {
+		c := &spaceSavingCounter{key: key, count: 1}
+		s.counters[key] = c
+		heap.Push(&s.heap, c)
+		return
+	}
+	// The counter having the lowest count is reassigned to the new key
+	c := s.heap[0]
+	delete(s.counters, c.key)
+	c.key = key
+	c.error = c.count
+	c.count++
+	s.counters[key] = c
+	heap.Fix(&s.heap, 0)
+}
+
+// top returns the k keys having the highest counts, the ties being broken by key order.
+func (s *spaceSaving) top(k int) []KeyCount {
+	counters := make([]*spaceSavingCounter, len(s.heap))
+	copy(counters, s.heap)
+	sort.Slice(counters, func(i, j int) bool {
+		if counters[i].count != counters[j].count {
+			return counters[i].count > counters[j].count
+		}
+		return compareKeys(counters[i].key, counters[j].key) < 0
+	})
+	if k < len(counters) {
+		counters = counters[:k]
+	}
+	result := make([]KeyCount, len(counters))
+	for i, c := range counters {
+		result[i] = KeyCount{Key: c.key, Count: c.count, Error: c.error}
+	}
+	return result
+}
+
+type spaceSavingHeap []*spaceSavingCounter
+
+func (h spaceSavingHeap) Len() int {
+	return len(h)
+}
+
+func (h spaceSavingHeap) Less(i, j int) bool {
+	return h[i].count < h[j].count
+}
+
+func (h spaceSavingHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *spaceSavingHeap) Push(x interface{}) {
+	c := x.(*spaceSavingCounter)
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *spaceSavingHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	c := old[n-1]
+	*h = old[:n-1]
+	return c
+}
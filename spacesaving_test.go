@@ -0,0 +1,32 @@
+package rxgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SpaceSaving_Exact(t *testing.T) {
+	s := newSpaceSaving(3)
+	for _, k := range []string{"a", "b", "a", "c", "a", "b"} {
+		s.add(k)
+	}
+	assert.Equal(t, []KeyCount{
+		{Key: "a", Count: 3},
+		{Key: "b", Count: 2},
+		{Key: "c", Count: 1},
+	}, s.top(5))
+}
+
+func Test_SpaceSaving_Eviction(t *testing.T) {
+	s := newSpaceSaving(2)
+	for _, k := range []string{"a", "a", "b", "c"} {
+		s.add(k)
+	}
+	// c replaces b and inherits its count
+	assert.Equal(t, []KeyCount{
+		{Key: "a", Count: 2},
+		{Key: "c", Count: 2, Error: 1},
+	}, s.top(2))
+	assert.Len(t, s.counters, 2)
+}
@@ -0,0 +1,336 @@
+package rxgo
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	spillSegmentPrefix = "rxgo-spill-"
+	// Suffix of the segment of a running observation
+	spillActiveSuffix = ".segment"
+	// Suffix of the segment left by a canceled observation, until it is adopted by another observation
+	spillPendingSuffix = ".pending"
+	spillKindValue     = byte(0)
+	spillKindError     = byte(1)
+)
+
+type spillBuffer struct {
+	dir      string
+	maxBytes int64
+	codec    Codec
+}
+
+// spillSegment is an append-only file of items.
+// Each record is prefixed by its length, followed by its kind (a value or an error) and by its payload.
+// The file is truncated once every record is read.
+type spillSegment struct {
+	file        *os.File
+	readOffset  int64
+	writeOffset int64
+	maxBytes    int64
+	codec       Codec
+}
+
+// openSpillSegment creates the segment of an observation, in a directory. The records left by the previous
+// canceled observations are read first. The active segment of an observation whose process exited without
+// being canceled is not adopted, as it cannot be told apart from the segment of a running observation.
+func openSpillSegment(spill *spillBuffer) (*spillSegment, error) {
+	if spill.codec == nil {
+		return nil, IllegalInputError{error: "spill buffer codec must not be nil"}
+	}
+	f, err := ioutil.TempFile(spill.dir, spillSegmentPrefix+"*"+spillActiveSuffix)
+	if err != nil {
+		return nil, err
+	}
+	segment := &spillSegment{
+		file:     f,
+		maxBytes: spill.maxBytes,
+		codec:    spill.codec,
+	}
+	if err := segment.adoptPending(spill.dir); err != nil {
+		segment.remove()
+		return nil, err
+	}
+	return segment, nil
+}
+
+// adoptPending appends the segments left by the canceled observations of a directory.
+func (s *spillSegment) adoptPending(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, spillSegmentPrefix+"*"+spillPendingSuffix))
+	if err != nil {
+		return err
+	}
+	claimed := strings.TrimSuffix(s.file.Name(), spillActiveSuffix) + ".claimed"
+	for _, path := range paths {
+		// The segment is claimed first as another observation may adopt it at the same time
+		if err := os.Rename(path, claimed); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		f, err := os.Open(claimed)
+		if err != nil {
+			return err
+		}
+		n, err := io.Copy(s.file, f)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+		s.writeOffset += n
+		if err := os.Remove(claimed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *spillSegment) pending() bool {
+	return s.readOffset < s.writeOffset
+}
+
+func (s *spillSegment) encode(item Item) ([]byte, error) {
+	var kind byte
+	var payload []byte
+	if item.Error() {
+		kind = spillKindError
+		payload = []byte(item.E.Error())
+	} else {
+		b, err := s.codec.Marshal(item.V)
+		if err != nil {
+			return nil, err
+		}
+		kind = spillKindValue
+		payload = b
+	}
+
+	record := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+1+len(payload))
+	n := binary.PutUvarint(record, uint64(1+len(payload)))
+	record = append(record[:n], kind)
+	return append(record, payload...), nil
+}
+
+// append writes an item at the end of the segment. It returns false if the segment would exceed its maximum size.
+func (s *spillSegment) append(item Item) (bool, error) {
+	record, err := s.encode(item)
+	if err != nil {
+		return false, err
+	}
+	if s.maxBytes > 0 && s.writeOffset+int64(len(record)) > s.maxBytes {
+		return false, nil
+	}
+	if _, err := s.file.WriteAt(record, s.writeOffset); err != nil {
+		return false, err
+	}
+	s.writeOffset += int64(len(record))
+	return true, nil
+}
+
+// read reads the next pending item.
+func (s *spillSegment) read() (Item, error) {
+	header := make([]byte, binary.MaxVarintLen64)
+	n, err := s.file.ReadAt(header, s.readOffset)
+	if err != nil && err != io.EOF {
+		return Item{}, err
+	}
+	length, m := binary.Uvarint(header[:n])
+	if m <= 0 || length == 0 {
+		return Item{}, errors.New("corrupted spill segment")
+	}
+	record := make([]byte, length)
+	if _, err := s.file.ReadAt(record, s.readOffset+int64(m)); err != nil {
+		return Item{}, err
+	}
+	s.readOffset += int64(m) + int64(length)
+
+	var item Item
+	if record[0] == spillKindError {
+		// Only the message of an error is spilled
+		item = Error(errors.New(string(record[1:])))
+	} else {
+		v, err := s.codec.Unmarshal(record[1:])
+		if err != nil {
+			return Item{}, err
+		}
+		item = Of(v)
+	}
+
+	if !s.pending() {
+		if err := s.reset(); err != nil {
+			return Item{}, err
+		}
+	}
+	return item, nil
+}
+
+func (s *spillSegment) reset() error {
+	s.readOffset = 0
+	s.writeOffset = 0
+	return s.file.Truncate(0)
+}
+
+// compact rewrites the segment with the given items followed by the pending records, and leaves it to be read
+// first by the next observation.
+func (s *spillSegment) compact(items []Item) error {
+	path := s.file.Name()
+	tmp, err := os.Create(path + ".tmp")
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		record, err := s.encode(item)
+		if err == nil {
+			_, err = tmp.Write(record)
+		}
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+			return err
+		}
+	}
+	if _, err := io.Copy(tmp, io.NewSectionReader(s.file, s.readOffset, s.writeOffset-s.readOffset)); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	s.remove()
+	return os.Rename(tmp.Name(), strings.TrimSuffix(path, spillActiveSuffix)+spillPendingSuffix)
+}
+
+func (s *spillSegment) remove() {
+	_ = s.file.Close()
+	_ = os.Remove(s.file.Name())
+}
+
+// relaySpill forwards the items to out, buffering up to capacity items in memory and spilling the next ones to
+// the segment file. If the context is canceled, the items not consumed yet are kept in the segment file.
+func relaySpill(ctx context.Context, in <-chan Item, out chan<- Item, capacity int, spill *spillBuffer, onDrop func(Item)) {
+	segment, err := openSpillSegment(spill)
+	if err != nil {
+		Error(err).SendContext(ctx, out)
+		close(out)
+		discard(ctx, in, onDrop)
+		return
+	}
+	defer close(out)
+
+	buffer := newRingBuffer(capacity)
+	// Once the segment cannot be written, the next items are dropped and failure is emitted after the
+	// pending items. After an overflow, the items are spilled again once failure is emitted, whereas after an
+	// I/O error, they are dropped until the end of the observation.
+	failed := false
+	overflowed := false
+	var failure *Item
+	fail := func(err error) {
+		failed = true
+		item := Error(err)
+		failure = &item
+	}
+	drop := func(item Item) {
+		if onDrop != nil {
+			onDrop(item)
+		}
+	}
+
+	for in != nil || buffer.len() > 0 || segment.pending() || failure != nil {
+		for !buffer.full() && segment.pending() {
+			item, err := segment.read()
+			if err != nil {
+				// The records left cannot be read anymore
+				_ = segment.reset()
+				fail(err)
+				break
+			}
+			buffer.push(item)
+		}
+
+		// A nil channel is never selected
+		var send chan<- Item
+		var head Item
+		if buffer.len() > 0 {
+			send = out
+			head = buffer.peek()
+		} else if failure != nil && !segment.pending() {
+			send = out
+			head = *failure
+		}
+
+		select {
+		case <-ctx.Done():
+			items := make([]Item, 0, buffer.len())
+			for buffer.len() > 0 {
+				items = append(items, buffer.pop())
+			}
+			if len(items) == 0 && !segment.pending() {
+				segment.remove()
+				return
+			}
+			_ = segment.compact(items)
+			return
+		case send <- head:
+			if buffer.len() > 0 {
+				buffer.pop()
+			} else {
+				failure = nil
+				// The segment is drained, so there is room again
+				overflowed = false
+			}
+		case item, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+			if failed || overflowed {
+				drop(item)
+				continue
+			}
+			if !buffer.full() && !segment.pending() {
+				buffer.push(item)
+				continue
+			}
+			written, err := segment.append(item)
+			if err != nil {
+				fail(err)
+				drop(item)
+				continue
+			}
+			if !written {
+				overflowed = true
+				overflow := Error(BufferOverflowError{error: fmt.Sprintf("spill buffer of %d bytes exceeded", spill.maxBytes)})
+				failure = &overflow
+				drop(item)
+			}
+		}
+	}
+	segment.remove()
+}
+
+// discard consumes the items of a channel until it is closed or until the context is canceled.
+func discard(ctx context.Context, in <-chan Item, onDrop func(Item)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case item, ok := <-in:
+			if !ok {
+				return
+			}
+			if onDrop != nil {
+				onDrop(item)
+			}
+		}
+	}
+}
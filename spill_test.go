@@ -0,0 +1,197 @@
+package rxgo
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+var spillItems = []interface{}{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+
+func spillSource(items ...interface{}) Observable {
+	ch := make(chan Item, len(items))
+	for _, item := range items {
+		ch <- Of(item)
+	}
+	close(ch)
+	return FromChannel(ch)
+}
+
+// awaitSpilled waits until the segment files of the running observations of a directory reach a total size.
+func awaitSpilled(t *testing.T, dir string, size int64) {
+	assert.Eventually(t, func() bool {
+		paths, err := filepath.Glob(filepath.Join(dir, spillSegmentPrefix+"*"+spillActiveSuffix))
+		if err != nil {
+			return false
+		}
+		total := int64(0)
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil {
+				return false
+			}
+			total += info.Size()
+		}
+		return total == size
+	}, time.Second, time.Millisecond)
+}
+
+func Test_SpillBuffer(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir, err := ioutil.TempDir("", "rxgo-spill")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	observe := spillSource(spillItems...).Map(identity, WithContext(ctx), WithBufferedChannel(2),
+		WithSpillBuffer(dir, 0, stringCodec{})).Observe()
+	// 8 records of 3 bytes
+	awaitSpilled(t, dir, 24)
+
+	Assert(ctx, t, &ObservableImpl{iterable: newChannelIterable(observe)}, HasItems(spillItems...), HasNoError())
+	// The segment file is removed once every item is emitted
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func Test_SpillBuffer_Restart(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	dir, err := ioutil.TempDir("", "rxgo-spill")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	observe := spillSource(spillItems...).Map(identity, WithContext(ctx1), WithBufferedChannel(2),
+		WithSpillBuffer(dir, 0, stringCodec{})).Observe()
+	awaitSpilled(t, dir, 24)
+	cancel1()
+	consumed, err := collect(context.Background(), observe)
+	assert.NoError(t, err)
+
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	replayed, err := collect(ctx2, Empty().Map(identity, WithContext(ctx2),
+		WithSpillBuffer(dir, 0, stringCodec{})).Observe())
+	assert.NoError(t, err)
+	assert.Equal(t, spillItems, append(consumed, replayed...))
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func Test_SpillBuffer_SharedDirectory(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir, err := ioutil.TempDir("", "rxgo-spill")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	obs := spillSource(spillItems...).Map(identity, WithContext(ctx), WithBufferedChannel(2),
+		WithSpillBuffer(dir, 0, stringCodec{}))
+	other := spillSource("x", "y", "z", "w").Map(identity, WithContext(ctx), WithBufferedChannel(2),
+		WithSpillBuffer(dir, 0, stringCodec{}))
+	observe, otherObserve := obs.Observe(), other.Observe()
+	// Each observation spills to its own segment: 8 and 2 records of 3 bytes
+	awaitSpilled(t, dir, 30)
+
+	items, err := collect(ctx, observe)
+	assert.NoError(t, err)
+	assert.Equal(t, spillItems, items)
+	items, err = collect(ctx, otherObserve)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"x", "y", "z", "w"}, items)
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func Test_SpillBuffer_MaxBytes(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir, err := ioutil.TempDir("", "rxgo-spill")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var dropped int32
+	observe := spillSource(spillItems...).Map(identity, WithContext(ctx), WithBufferedChannel(2),
+		WithSpillBuffer(dir, 9, stringCodec{}), WithOnDrop(func(Item) {
+			atomic.AddInt32(&dropped, 1)
+		})).Observe()
+	awaitDrops(t, &dropped, 5)
+
+	Assert(ctx, t, &ObservableImpl{iterable: newChannelIterable(observe)}, HasItems("a", "b", "c", "d", "e"),
+		HasError(BufferOverflowError{error: "spill buffer of 9 bytes exceeded"}))
+}
+
+func Test_SpillBuffer_MaxBytes_Recover(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir, err := ioutil.TempDir("", "rxgo-spill")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ch := make(chan Item)
+	var dropped int32
+	observe := FromChannel(ch).Map(identity, WithContext(ctx), WithBufferedChannel(2),
+		WithSpillBuffer(dir, 9, stringCodec{}), WithOnDrop(func(Item) {
+			atomic.AddInt32(&dropped, 1)
+		})).Observe()
+	for _, item := range spillItems[:6] {
+		ch <- Of(item)
+	}
+	awaitDrops(t, &dropped, 1)
+
+	for _, item := range spillItems[:5] {
+		assert.Equal(t, item, (<-observe).V)
+	}
+	assert.Equal(t, BufferOverflowError{error: "spill buffer of 9 bytes exceeded"}, (<-observe).E)
+	// The segment is drained, so the next items are accepted again
+	ch <- Of("g")
+	close(ch)
+	assert.Equal(t, "g", (<-observe).V)
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_SpillBuffer_Error(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir, err := ioutil.TempDir("", "rxgo-spill")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	observe := testObservable(ctx, "a", "b", errFoo, "c").Map(identity, WithContext(ctx),
+		WithErrorStrategy(ContinueOnError), WithSpillBuffer(dir, 0, stringCodec{})).Observe()
+	// The records of "b", errFoo and "c"
+	awaitSpilled(t, dir, 11)
+
+	items, err := collect(ctx, observe)
+	assert.NoError(t, err)
+	assert.Len(t, items, 4)
+	assert.Equal(t, "a", items[0])
+	assert.Equal(t, "b", items[1])
+	assert.EqualError(t, items[2].(error), errFoo.Error())
+	assert.Equal(t, "c", items[3])
+}
+
+func Test_SpillBuffer_NilCodec(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obs := testObservable(ctx, "a").Map(identity, WithContext(ctx), WithSpillBuffer("", 0, nil))
+	Assert(ctx, t, obs, HasAnError())
+}
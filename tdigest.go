@@ -0,0 +1,124 @@
+package rxgo
+
+import (
+	"math"
+	"sort"
+)
+
+// tDigest is a merging t-digest, a sketch estimating the quantiles of a stream with a bounded memory.
+// The accuracy is higher close to the extreme quantiles.
+type tDigest struct {
+	compression float64
+	// Merged centroids, sorted by mean
+	centroids []centroid
+	// Centroids not merged yet
+	buffer []centroid
+	count  float64
+	min    float64
+	max    float64
+}
+
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+func newTDigest(compression float64) *tDigest {
+	return &tDigest{
+		compression: compression,
+		centroids:   make([]centroid, 0),
+		buffer:      make([]centroid, 0),
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+func (d *tDigest) add(x float64) {
+	d.addCentroid(centroid{mean: x, weight: 1}, x, x)
+}
+
+// merge adds every centroid of another digest.
+func (d *tDigest) merge(other *tDigest) {
+	other.compress()
+	for _, c := range other.centroids {
+		d.addCentroid(c, other.min, other.max)
+	}
+}
+
+func (d *tDigest) addCentroid(c centroid, min, max float64) {
+	d.buffer = append(d.buffer, c)
+	d.count += c.weight
+	d.min = math.Min(d.min, min)
+	d.max = math.Max(d.max, max)
+	if len(d.buffer) >= int(5*d.compression) {
+		d.compress()
+	}
+}
+
+// k is the scale function bounding the size of the centroids: a centroid spans at most one unit of k.
+func (d *tDigest) k(q float64) float64 {
+	return d.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+func (d *tDigest) compress() {
+	if len(d.buffer) == 0 {
+		return
+	}
+	all := append(d.centroids, d.buffer...)
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].mean < all[j].mean
+	})
+
+	merged := make([]centroid, 0, len(all))
+	current := all[0]
+	weightSoFar := 0.
+	for _, c := range all[1:] {
+		qLeft := weightSoFar / d.count
+		qRight := (weightSoFar + current.weight + c.weight) / d.count
+		if d.k(qRight)-d.k(qLeft) <= 1 {
+			current.mean += (c.mean - current.mean) * c.weight / (current.weight + c.weight)
+			current.weight += c.weight
+			continue
+		}
+		merged = append(merged, current)
+		weightSoFar += current.weight
+		current = c
+	}
+	d.centroids = append(merged, current)
+	d.buffer = d.buffer[:0]
+}
+
+// quantile estimates the value at a quantile between 0 and 1, interpolating between the centroids.
+func (d *tDigest) quantile(q float64) float64 {
+	d.compress()
+	n := len(d.centroids)
+	switch {
+	case n == 0:
+		return math.NaN()
+	case n == 1:
+		return d.centroids[0].mean
+	case q <= 0:
+		return d.min
+	case q >= 1:
+		return d.max
+	}
+
+	target := q * d.count
+	first := d.centroids[0]
+	if target < first.weight/2 {
+		return d.min + (first.mean-d.min)*target/(first.weight/2)
+	}
+	cumulative := 0.
+	for i := 0; i < n-1; i++ {
+		c, next := d.centroids[i], d.centroids[i+1]
+		left := cumulative + c.weight/2
+		right := cumulative + c.weight + next.weight/2
+		if target <= right {
+			return c.mean + (next.mean-c.mean)*(target-left)/(right-left)
+		}
+		cumulative += c.weight
+	}
+	last := d.centroids[n-1]
+	center := d.count - last.weight/2
+	return last.mean + (d.max-last.mean)*math.Min(1, (target-center)/(last.weight/2))
+}
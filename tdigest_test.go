@@ -0,0 +1,41 @@
+package rxgo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TDigest_Empty(t *testing.T) {
+	d := newTDigest(100)
+	assert.True(t, math.IsNaN(d.quantile(0.5)))
+}
+
+func Test_TDigest_Uniform(t *testing.T) {
+	d := newTDigest(100)
+	for _, v := range rand.Perm(100000) {
+		d.add(float64(v))
+	}
+	assert.Equal(t, 0., d.quantile(0))
+	assert.Equal(t, 99999., d.quantile(1))
+	for _, q := range []float64{0.01, 0.1, 0.5, 0.9, 0.99, 0.999} {
+		assert.InDelta(t, q*100000, d.quantile(q), 100000*0.005, "quantile %v", q)
+	}
+	// The memory is bounded by the compression
+	assert.Less(t, len(d.centroids), 200)
+}
+
+func Test_TDigest_Merge(t *testing.T) {
+	d1 := newTDigest(100)
+	d2 := newTDigest(100)
+	for i := 0; i < 50000; i++ {
+		d1.add(float64(i))
+		d2.add(float64(50000 + i))
+	}
+	d1.merge(d2)
+	assert.Equal(t, 0., d1.quantile(0))
+	assert.Equal(t, 99999., d1.quantile(1))
+	assert.InDelta(t, 50000, d1.quantile(0.5), 500)
+}
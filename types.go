@@ -6,6 +6,8 @@ type (
 	operatorOptions struct {
 		stop          func()
 		resetIterable func(Iterable)
+		name          string
+		deadLetter    chan<- Item
 	}
 
 	// Comparator defines a func that returns an int:
@@ -17,6 +19,10 @@ type (
 	ItemToObservable func(Item) Observable
 	// ErrorToObservable defines a function that transforms an observable from an error.
 	ErrorToObservable func(error) Observable
+	// ItemToSingle defines a function that computes a single from an item.
+	ItemToSingle func(Item) Single
+	// ErrorToSingle defines a function that computes a single from an error.
+	ErrorToSingle func(error) Single
 	// Func defines a function that computes a value from an input value.
 	Func func(context.Context, interface{}) (interface{}, error)
 	// Func2 defines a function that computes a value from two input values.
@@ -31,6 +37,12 @@ type (
 	Marshaller func(interface{}) ([]byte, error)
 	// Unmarshaller defines an unmarshaller type ([]byte to interface).
 	Unmarshaller func([]byte, interface{}) error
+	// Codec defines how an item is encoded to and decoded from bytes, to be stored outside of the memory.
+	// Decoding an encoded item must return an item of the same type.
+	Codec interface {
+		Marshal(interface{}) ([]byte, error)
+		Unmarshal([]byte) (interface{}, error)
+	}
 	// Producer defines a producer implementation.
 	Producer func(ctx context.Context, next chan<- Item)
 	// Supplier defines a function that supplies a result from nothing.
@@ -56,6 +68,12 @@ const (
 	Block BackpressureStrategy = iota
 	// Drop drops the message.
 	Drop
+	// Latest keeps only the most recent message until the channel is available, dropping the previous one.
+	Latest
+	// DropOldest buffers the messages, dropping the oldest one once the buffer is full.
+	DropOldest
+	// BufferThenError buffers the messages and emits a BufferOverflowError once the buffer is full.
+	BufferThenError
 )
 
 // OnErrorStrategy is the Observable error strategy.
@@ -78,3 +96,25 @@ const (
 	// Eager means consuming as soon as the Observable is created.
 	Eager
 )
+
+// JoinType defines which items a keyed join emits.
+type JoinType uint32
+
+const (
+	// InnerJoin emits only the matching pairs.
+	InnerJoin JoinType = iota
+	// LeftOuterJoin also emits the left items without match, paired with nil.
+	LeftOuterJoin
+	// FullOuterJoin also emits the left and right items without match, paired with nil.
+	FullOuterJoin
+)
+
+// GroupEvictionPolicy defines which group is completed once the maximum number of groups is reached.
+type GroupEvictionPolicy uint32
+
+const (
+	// EvictLeastRecentlyUsed completes the group having received an item the least recently.
+	EvictLeastRecentlyUsed GroupEvictionPolicy = iota
+	// EvictOldest completes the group created first.
+	EvictOldest
+)